@@ -0,0 +1,143 @@
+package httpstat
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Checkpoint is a snapshot of content transfer progress.
+type Checkpoint struct {
+	// Bytes is the number of body bytes read so far.
+	Bytes int64
+
+	// Elapsed is the time since the first response byte.
+	Elapsed time.Duration
+}
+
+// BodyOption configures the body returned by WrapBody.
+type BodyOption func(*body)
+
+// CheckpointEveryBytes records a checkpoint each time at least n more
+// bytes have been read.
+func CheckpointEveryBytes(n int64) BodyOption {
+	return func(b *body) {
+		b.everyBytes = n
+	}
+}
+
+// CheckpointEvery records a checkpoint each time at least d has passed
+// since the previous one.
+func CheckpointEvery(d time.Duration) BodyOption {
+	return func(b *body) {
+		b.every = d
+	}
+}
+
+// FinalizeOnBodyClose calls End on the result when the body is closed,
+// so content transfer and total are measured without calling End
+// manually.
+func FinalizeOnBodyClose() BodyOption {
+	return func(b *body) {
+		b.finalize = true
+	}
+}
+
+// WrapBody wraps a response body so that progress of content transfer is
+// recorded on r as Checkpoints. A final checkpoint is always recorded when
+// the body reaches EOF.
+func WrapBody(rc io.ReadCloser, r *Result, opts ...BodyOption) io.ReadCloser {
+	if r.mu == nil {
+		r.mu = &sync.Mutex{}
+	}
+
+	b := &body{
+		ReadCloser: rc,
+		r:          r,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	r.mu.Lock()
+	b.start = r.transferStart
+	r.mu.Unlock()
+
+	// When trace is not used, measure from wrapping time.
+	if b.start.IsZero() {
+		b.start = time.Now()
+	}
+	b.last = b.start
+
+	return b
+}
+
+type body struct {
+	io.ReadCloser
+
+	r *Result
+
+	everyBytes int64
+	every      time.Duration
+	finalize   bool
+	once       sync.Once
+
+	start     time.Time
+	last      time.Time
+	read      int64
+	lastBytes int64
+}
+
+func (b *body) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+
+	now := time.Now()
+	switch {
+	case err == io.EOF && b.read != b.lastBytes:
+		b.checkpoint(now)
+	case n == 0:
+	case b.everyBytes > 0 && b.read-b.lastBytes >= b.everyBytes:
+		b.checkpoint(now)
+	case b.every > 0 && now.Sub(b.last) >= b.every:
+		b.checkpoint(now)
+	}
+
+	return n, err
+}
+
+func (b *body) Close() error {
+	err := b.ReadCloser.Close()
+	if b.finalize {
+		b.once.Do(func() {
+			b.r.End(time.Now())
+		})
+	}
+	return err
+}
+
+func (b *body) checkpoint(now time.Time) {
+	b.last = now
+	b.lastBytes = b.read
+
+	b.r.mu.Lock()
+	defer b.r.mu.Unlock()
+
+	b.r.checkpoints = append(b.r.checkpoints, Checkpoint{
+		Bytes:   b.read,
+		Elapsed: now.Sub(b.start),
+	})
+}
+
+// Checkpoints returns the content transfer progress recorded by the body
+// returned from WrapBody.
+func (r *Result) Checkpoints() []Checkpoint {
+	if r.mu == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Checkpoint(nil), r.checkpoints...)
+}
@@ -0,0 +1,101 @@
+package httpstat
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrapBody_Checkpoints(t *testing.T) {
+	chunk := strings.Repeat("x", 4096)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 4; i++ {
+			io.WriteString(w, chunk)
+			w.(http.Flusher).Flush()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}))
+	defer ts.Close()
+
+	var result Result
+	req := NewRequest(t, ts.URL, &result)
+
+	res, err := DefaultClient().Do(req)
+	if err != nil {
+		t.Fatal("client.Do failed:", err)
+	}
+
+	body := WrapBody(res.Body, &result, CheckpointEveryBytes(1024))
+	n, err := io.Copy(ioutil.Discard, body)
+	if err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+	body.Close()
+	result.End(time.Now())
+
+	checkpoints := result.Checkpoints()
+	if len(checkpoints) < 2 {
+		t.Fatalf("got %d checkpoints, want at least 2", len(checkpoints))
+	}
+
+	for i := 1; i < len(checkpoints); i++ {
+		prev, cur := checkpoints[i-1], checkpoints[i]
+		if cur.Bytes <= prev.Bytes {
+			t.Fatalf("#%d bytes %d should be greater than %d", i, cur.Bytes, prev.Bytes)
+		}
+		if cur.Elapsed <= prev.Elapsed {
+			t.Fatalf("#%d elapsed %s should be greater than %s", i, cur.Elapsed, prev.Elapsed)
+		}
+	}
+
+	if got, want := checkpoints[len(checkpoints)-1].Bytes, n; got != want {
+		t.Fatalf("last checkpoint bytes = %d, want %d", got, want)
+	}
+}
+
+func TestWrapBody_FinalizeOnBodyClose(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	var result Result
+	req := NewRequest(t, ts.URL, &result)
+
+	res, err := DefaultClient().Do(req)
+	if err != nil {
+		t.Fatal("client.Do failed:", err)
+	}
+
+	body := WrapBody(res.Body, &result, FinalizeOnBodyClose())
+	if _, err := io.Copy(ioutil.Discard, body); err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+
+	if result.IsFinalized() {
+		t.Fatal("IsFinalized should be false before Close")
+	}
+
+	body.Close()
+
+	if !result.IsFinalized() {
+		t.Fatal("IsFinalized should be true after Close")
+	}
+
+	if result.total <= 0 {
+		t.Fatalf("expect total %d to be non-zero", result.total)
+	}
+
+	// Closing again must not move the end.
+	endedAt, total := result.EndedAt(), result.total
+	time.Sleep(time.Millisecond)
+	body.Close()
+
+	if !result.EndedAt().Equal(endedAt) || result.total != total {
+		t.Fatal("second Close should not call End again")
+	}
+}
@@ -0,0 +1,76 @@
+package httpstat
+
+import (
+	"sort"
+	"time"
+)
+
+// budgetNames are the phases covered by SuggestBudget.
+var budgetNames = append(append([]string(nil), phaseNames...), "Total")
+
+// SuggestBudget returns the p-th percentile (0-100) of each phase and of
+// the total over results. It can be used as a starting point of latency
+// budgets which are checked by CheckBudget. Percentiles are computed by
+// linear interpolation.
+func SuggestBudget(results []*Result, p float64) map[string]time.Duration {
+	var a Aggregator
+	for _, r := range results {
+		a.Add(r)
+	}
+	return a.SuggestBudget(p)
+}
+
+// SuggestBudget is like the package level SuggestBudget but over the
+// Results added to a, using its Estimators.
+func (a *Aggregator) SuggestBudget(p float64) map[string]time.Duration {
+	budget := make(map[string]time.Duration, len(budgetNames))
+	for _, name := range budgetNames {
+		budget[name] = a.Percentile(name, p)
+	}
+	return budget
+}
+
+// CheckBudget returns the names of the phases which took longer than their
+// budget, sorted by name. Phases missing from budget are not checked.
+func (r *Result) CheckBudget(budget map[string]time.Duration) []string {
+	var exceeded []string
+	for name, max := range budget {
+		if r.duration(name) > max {
+			exceeded = append(exceeded, name)
+		}
+	}
+	sort.Strings(exceeded)
+	return exceeded
+}
+
+// TierThreshold is a latency tier used by Tier. A Max of zero means no
+// upper bound.
+type TierThreshold struct {
+	Name string
+	Max  time.Duration
+}
+
+// DefaultTiers are common latency tiers for Tier.
+var DefaultTiers = []TierThreshold{
+	{Name: "fast", Max: 100 * time.Millisecond},
+	{Name: "ok", Max: 500 * time.Millisecond},
+	{Name: "slow", Max: 2 * time.Second},
+	{Name: "critical"},
+}
+
+// Tier returns the name of the first of thresholds whose Max is greater
+// than the total, so thresholds must be sorted by Max with the unbounded
+// one last. It returns an empty string when no tier matches, or when End
+// has not been called, since the total is not measured then.
+func (r *Result) Tier(thresholds []TierThreshold) string {
+	if !r.isFinalized {
+		return ""
+	}
+
+	for _, t := range thresholds {
+		if t.Max <= 0 || r.total < t.Max {
+			return t.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package httpstat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSuggestBudget(t *testing.T) {
+	// Phases of i-th result are i, 2i, 3i, 4i and 5i ms (i = 1..100).
+	var results []*Result
+	for i := 100; i >= 1; i-- {
+		d := time.Duration(i) * time.Millisecond
+		results = append(results, &Result{
+			DNSLookup:        d,
+			TCPConnection:    2 * d,
+			TLSHandshake:     3 * d,
+			ServerProcessing: 4 * d,
+			contentTransfer:  5 * d,
+			total:            15 * d,
+		})
+	}
+
+	budget := SuggestBudget(results, 90)
+
+	// p90 of 1..100 is 90.1 by linear interpolation.
+	p90 := 90100 * time.Microsecond
+	want := map[string]time.Duration{
+		"DNSLookup":        p90,
+		"TCPConnection":    2 * p90,
+		"TLSHandshake":     3 * p90,
+		"ServerProcessing": 4 * p90,
+		"ContentTransfer":  5 * p90,
+		"Total":            15 * p90,
+	}
+
+	if len(budget) != len(want) {
+		t.Fatalf("got %d phases, want %d", len(budget), len(want))
+	}
+
+	for name, d := range want {
+		if got := budget[name]; got != d {
+			t.Fatalf("%s = %s, want %s", name, got, d)
+		}
+	}
+
+	// Results within the suggested budget pass it.
+	if got := results[50].CheckBudget(budget); len(got) != 0 {
+		t.Fatalf("CheckBudget = %v, want none", got)
+	}
+
+	// The slowest one exceeds all phases.
+	if got := results[0].CheckBudget(budget); len(got) != len(want) {
+		t.Fatalf("CheckBudget = %v, want all phases", got)
+	}
+}
+
+func TestCheckBudget(t *testing.T) {
+	budget := map[string]time.Duration{
+		"TLSHandshake":     10 * time.Millisecond,
+		"ServerProcessing": 50 * time.Millisecond,
+		"Total":            90 * time.Millisecond,
+	}
+
+	got := testResult().CheckBudget(budget)
+	want := []string{"TLSHandshake", "Total"}
+
+	if len(got) != len(want) {
+		t.Fatalf("CheckBudget = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("CheckBudget = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTier(t *testing.T) {
+	cases := []struct {
+		total time.Duration
+		want  string
+	}{
+		{99 * time.Millisecond, "fast"},
+		{100 * time.Millisecond, "ok"},
+		{499 * time.Millisecond, "ok"},
+		{time.Second, "slow"},
+		{2 * time.Second, "critical"},
+		{time.Minute, "critical"},
+	}
+
+	for _, tc := range cases {
+		r := &Result{total: tc.total, isFinalized: true}
+		if got := r.Tier(DefaultTiers); got != tc.want {
+			t.Fatalf("Tier of %s = %q, want %q", tc.total, got, tc.want)
+		}
+	}
+
+	// Not measured to completion.
+	unfinalized := &Result{ServerProcessing: 5 * time.Second}
+	if got := unfinalized.Tier(DefaultTiers); got != "" {
+		t.Fatalf("Tier of unfinalized result = %q, want none", got)
+	}
+
+	bounded := []TierThreshold{{Name: "fast", Max: 100 * time.Millisecond}}
+	if got := (&Result{total: time.Second, isFinalized: true}).Tier(bounded); got != "" {
+		t.Fatalf("Tier = %q, want none", got)
+	}
+}
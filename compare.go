@@ -0,0 +1,98 @@
+package httpstat
+
+import "time"
+
+// TLSMarginalCost returns the latency TLS adds on top of plain HTTP. It
+// compares the time between the end of TCP connection and Pretransfer
+// (the TLS handshake plus any overhead around it) of tlsResult with the
+// same span of plainResult, which is zero for plain HTTP.
+//
+// DNS lookup and TCP connection are excluded on purpose, so the two
+// results do not need to share the same network path. It assumes both
+// requests opened a fresh connection to comparable endpoints; a reused
+// connection has no handshake and yields zero. The result is never
+// negative.
+func TLSMarginalCost(tlsResult, plainResult *Result) time.Duration {
+	cost := (tlsResult.Pretransfer - tlsResult.Connect) - (plainResult.Pretransfer - plainResult.Connect)
+	if cost < 0 {
+		return 0
+	}
+	return cost
+}
+
+// RegressionAttribution returns how much each phase of current changed
+// compared to baseline. The time which is not covered by any phase (e.g.
+// the gap between connection and writing the request) is reported as
+// "Other", so the values always sum up to the change of the total.
+func RegressionAttribution(baseline, current *Result) map[string]time.Duration {
+	attribution := make(map[string]time.Duration, len(phaseNames)+1)
+
+	other := current.total - baseline.total
+	for _, name := range phaseNames {
+		delta := current.duration(name) - baseline.duration(name)
+		attribution[name] = delta
+		other -= delta
+	}
+	attribution["Other"] = other
+
+	return attribution
+}
+
+// RegressionShare is like RegressionAttribution but returns the share of
+// each phase in percent of the change of the total. When the total did
+// not change, all shares are zero.
+func RegressionShare(baseline, current *Result) map[string]float64 {
+	attribution := RegressionAttribution(baseline, current)
+	delta := current.total - baseline.total
+
+	share := make(map[string]float64, len(attribution))
+	for name, d := range attribution {
+		if delta == 0 {
+			share[name] = 0
+			continue
+		}
+		share[name] = float64(d) / float64(delta) * 100
+	}
+
+	return share
+}
+
+// ResumptionSavings estimates the time saved by TLS session resumption over
+// results, which mix full handshakes and resumptions (see DidResume). The
+// saving of one resumption is the mean TLSHandshake of full handshakes
+// minus the mean of resumed ones. observed is the saving of the resumed
+// handshakes, potential is the additional saving if all full handshakes
+// had been resumed as well.
+//
+// Results without a successful TLS handshake are ignored. When there are
+// no full or no resumed handshakes, both are zero.
+func ResumptionSavings(results []*Result) (observed time.Duration, potential time.Duration) {
+	var (
+		full, resumed           time.Duration
+		fullCount, resumedCount int
+	)
+	for _, r := range results {
+		if !r.isTLS || r.isReused || r.tlsErr != nil {
+			continue
+		}
+
+		if r.didResume {
+			resumed += r.TLSHandshake
+			resumedCount++
+		} else {
+			full += r.TLSHandshake
+			fullCount++
+		}
+	}
+
+	if fullCount == 0 || resumedCount == 0 {
+		return 0, 0
+	}
+
+	saving := full/time.Duration(fullCount) - resumed/time.Duration(resumedCount)
+	if saving < 0 {
+		return 0, 0
+	}
+
+	return saving * time.Duration(resumedCount), saving * time.Duration(fullCount)
+}
@@ -0,0 +1,123 @@
+package httpstat
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTLSMarginalCost(t *testing.T) {
+	tlsResult := &Result{
+		DNSLookup:     5 * time.Millisecond,
+		TCPConnection: 10 * time.Millisecond,
+		TLSHandshake:  30 * time.Millisecond,
+		NameLookup:    5 * time.Millisecond,
+		Connect:       15 * time.Millisecond,
+		Pretransfer:   47 * time.Millisecond, // 2ms between TCP and TLS start
+	}
+	plainResult := &Result{
+		DNSLookup:     20 * time.Millisecond,
+		TCPConnection: 12 * time.Millisecond,
+		NameLookup:    20 * time.Millisecond,
+		Connect:       32 * time.Millisecond,
+		Pretransfer:   32 * time.Millisecond,
+	}
+
+	if got, want := TLSMarginalCost(tlsResult, plainResult), 32*time.Millisecond; got != want {
+		t.Fatalf("TLSMarginalCost = %s, want %s", got, want)
+	}
+
+	// Plain HTTP against itself costs nothing.
+	if got, want := TLSMarginalCost(plainResult, plainResult), time.Duration(0); got != want {
+		t.Fatalf("TLSMarginalCost = %s, want %s", got, want)
+	}
+
+	// Reused connection has no handshake.
+	reused := &Result{isTLS: true, isReused: true}
+	if got, want := TLSMarginalCost(reused, plainResult), time.Duration(0); got != want {
+		t.Fatalf("TLSMarginalCost = %s, want %s", got, want)
+	}
+}
+
+func TestRegressionAttribution(t *testing.T) {
+	baseline := testResult()
+
+	current := testResult()
+	current.TLSHandshake += 40 * time.Millisecond
+	current.ServerProcessing += 8 * time.Millisecond
+	current.contentTransfer -= 3 * time.Millisecond
+	current.total += 50 * time.Millisecond // 5ms are not covered by phases
+
+	attribution := RegressionAttribution(baseline, current)
+
+	want := map[string]time.Duration{
+		"DNSLookup":        0,
+		"TCPConnection":    0,
+		"TLSHandshake":     40 * time.Millisecond,
+		"ServerProcessing": 8 * time.Millisecond,
+		"ContentTransfer":  -3 * time.Millisecond,
+		"Other":            5 * time.Millisecond,
+	}
+
+	if len(attribution) != len(want) {
+		t.Fatalf("got %d phases, want %d", len(attribution), len(want))
+	}
+
+	var sum time.Duration
+	for name, d := range attribution {
+		if got, want := d, want[name]; got != want {
+			t.Fatalf("%s = %s, want %s", name, got, want)
+		}
+		sum += d
+	}
+
+	if got, want := sum, current.total-baseline.total; got != want {
+		t.Fatalf("sum of attribution = %s, want %s", got, want)
+	}
+
+	share := RegressionShare(baseline, current)
+	if got, want := share["TLSHandshake"], 80.0; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("TLSHandshake share = %v, want %v", got, want)
+	}
+
+	var total float64
+	for _, s := range share {
+		total += s
+	}
+	if math.Abs(total-100) > 1e-9 {
+		t.Fatalf("sum of shares = %v, want 100", total)
+	}
+}
+
+func TestResumptionSavings(t *testing.T) {
+	handshake := func(d time.Duration, resumed bool) *Result {
+		return &Result{TLSHandshake: d, isTLS: true, didResume: resumed}
+	}
+
+	results := []*Result{
+		handshake(30*time.Millisecond, false),
+		handshake(34*time.Millisecond, false),
+		handshake(32*time.Millisecond, false),
+		handshake(10*time.Millisecond, true),
+		handshake(14*time.Millisecond, true),
+
+		// Ignored.
+		{isTLS: true, isReused: true},
+		{TCPConnection: 10 * time.Millisecond},
+	}
+
+	// Full is 32ms and resumed is 12ms on average, so each
+	// resumption saves 20ms.
+	observed, potential := ResumptionSavings(results)
+	if got, want := observed, 40*time.Millisecond; got != want {
+		t.Fatalf("observed = %s, want %s", got, want)
+	}
+	if got, want := potential, 60*time.Millisecond; got != want {
+		t.Fatalf("potential = %s, want %s", got, want)
+	}
+
+	observed, potential = ResumptionSavings(results[:3])
+	if observed != 0 || potential != 0 {
+		t.Fatalf("ResumptionSavings without resumption = %s, %s, want 0, 0", observed, potential)
+	}
+}
@@ -0,0 +1,25 @@
+package httpstat
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// DebugHandler returns a http.Handler which responds with Snapshots of the
+// Results kept by recent as a JSON array, oldest first. It is meant to be
+// mounted at /debug/httpstat.
+func DebugHandler(recent *Recent) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		results := recent.Results()
+
+		snapshots := make([]Snapshot, 0, len(results))
+		for _, r := range results {
+			snapshots = append(snapshots, r.Snapshot())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshots); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
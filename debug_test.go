@@ -0,0 +1,74 @@
+package httpstat
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDebugHandler(t *testing.T) {
+	recent := NewRecent(10)
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/httpstat", DebugHandler(recent))
+
+	for i := 1; i <= 3; i++ {
+		r := testResult()
+		r.total = time.Duration(i) * time.Second
+		recent.Add(r)
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/httpstat", nil))
+
+	if got, want := rec.Code, http.StatusOK; got != want {
+		t.Fatalf("status = %d, want %d", got, want)
+	}
+
+	if got, want := rec.Header().Get("Content-Type"), "application/json"; got != want {
+		t.Fatalf("Content-Type = %q, want %q", got, want)
+	}
+
+	var results []map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatal("json.Unmarshal failed:", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+
+	for i, r := range results {
+		if got, want := r["total"], float64(time.Duration(i+1)*time.Second); got != want {
+			t.Fatalf("#%d total = %v, want %v", i, got, want)
+		}
+		if got, want := r["dns_lookup"], float64(5*time.Millisecond); got != want {
+			t.Fatalf("#%d dns_lookup = %v, want %v", i, got, want)
+		}
+		if got, want := r["is_tls"], true; got != want {
+			t.Fatalf("#%d is_tls = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestResult_JSON(t *testing.T) {
+	// DebugHandler must not change how Result itself is encoded.
+	b, err := json.Marshal(testResult())
+	if err != nil {
+		t.Fatal("json.Marshal failed:", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal("json.Unmarshal failed:", err)
+	}
+
+	if got, want := m["DNSLookup"], float64(5*time.Millisecond); got != want {
+		t.Fatalf("DNSLookup = %v, want %v", got, want)
+	}
+	if _, ok := m["dns_lookup"]; ok {
+		t.Fatal("dns_lookup should not be encoded")
+	}
+}
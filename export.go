@@ -0,0 +1,114 @@
+package httpstat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VegetaLine returns the result as a single JSON line using vegeta's
+// "latency" field name for the total time, plus "waiting"
+// (ServerProcessing) and "connecting" (TCPConnection). All values are in
+// nanoseconds. It's not a complete vegeta result, since it has no status
+// code or timestamp.
+func (r *Result) VegetaLine() string {
+	return fmt.Sprintf(`{"latency":%d,"waiting":%d,"connecting":%d}`,
+		int64(r.total), int64(r.ServerProcessing), int64(r.TCPConnection))
+}
+
+// K6Metric returns the result mapped to k6 http_req_* metrics. Like k6,
+// all values are in milliseconds and http_req_duration excludes the time
+// spent on DNS lookup and connection setup.
+func (r *Result) K6Metric() map[string]float64 {
+	return map[string]float64{
+		"http_req_blocked":         ms(r.DNSLookup),
+		"http_req_connecting":      ms(r.TCPConnection),
+		"http_req_tls_handshaking": ms(r.TLSHandshake),
+		"http_req_waiting":         ms(r.ServerProcessing),
+		"http_req_receiving":       ms(r.contentTransfer),
+		"http_req_duration":        ms(r.ServerProcessing + r.contentTransfer),
+	}
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// WriteGnuplot writes results as a whitespace separated table which can be
+// plotted by gnuplot. It starts with a comment header naming the columns,
+// followed by one row per Result. Durations are in milliseconds.
+func WriteGnuplot(w io.Writer, results []*Result) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "# index dns tcp tls server transfer total (ms)")
+	for i, r := range results {
+		fmt.Fprintf(bw, "%d %.3f %.3f %.3f %.3f %.3f %.3f\n", i,
+			ms(r.DNSLookup), ms(r.TCPConnection), ms(r.TLSHandshake),
+			ms(r.ServerProcessing), ms(r.contentTransfer), ms(r.total))
+	}
+
+	return bw.Flush()
+}
+
+// TemplateRow is a phase of a Result as returned by TemplateData.
+type TemplateRow struct {
+	Name     string
+	Duration time.Duration
+
+	// Percent is the share of the phase in the total.
+	Percent float64
+
+	// IsTLS is true for the TLSHandshake row when the connection uses TLS.
+	IsTLS bool
+}
+
+// TemplateData returns the phases in waterfall order, so a html/template
+// or text/template can range over them directly.
+func (r *Result) TemplateData() []TemplateRow {
+	phases := r.phases()
+
+	rows := make([]TemplateRow, 0, len(phases))
+	for _, p := range phases {
+		row := TemplateRow{
+			Name:     p.name,
+			Duration: p.duration,
+			IsTLS:    p.name == "TLSHandshake" && r.isTLS,
+		}
+		if r.total > 0 {
+			row.Percent = float64(p.duration) / float64(r.total) * 100
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// WriteLokiLine writes r as a line for Grafana Loki: the stream labels in
+// braces, the timestamp t in nanoseconds and a logfmt body of the phase
+// durations, e.g.
+//
+//	{job="httpstat"} 1628000000000000000 dns_lookup=5ms ... total=100ms
+//
+// Labels are sorted by name.
+func WriteLokiLine(w io.Writer, labels map[string]string, r *Result, t time.Time) error {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, name+"="+strconv.Quote(labels[name]))
+	}
+
+	_, err := fmt.Fprintf(w, "{%s} %d dns_lookup=%s tcp_connection=%s tls_handshake=%s server_processing=%s content_transfer=%s total=%s\n",
+		strings.Join(pairs, ", "), t.UnixNano(),
+		r.DNSLookup, r.TCPConnection, r.TLSHandshake,
+		r.ServerProcessing, r.contentTransfer, r.total)
+	return err
+}
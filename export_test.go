@@ -0,0 +1,139 @@
+package httpstat
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func testResult() *Result {
+	return &Result{
+		DNSLookup:        5 * time.Millisecond,
+		TCPConnection:    10 * time.Millisecond,
+		TLSHandshake:     20 * time.Millisecond,
+		ServerProcessing: 40 * time.Millisecond,
+		contentTransfer:  25 * time.Millisecond,
+
+		NameLookup:    5 * time.Millisecond,
+		Connect:       15 * time.Millisecond,
+		Pretransfer:   35 * time.Millisecond,
+		StartTransfer: 75 * time.Millisecond,
+		total:         100 * time.Millisecond,
+
+		isTLS: true,
+	}
+}
+
+func TestVegetaLine(t *testing.T) {
+	got := testResult().VegetaLine()
+	want := `{"latency":100000000,"waiting":40000000,"connecting":10000000}`
+	if got != want {
+		t.Fatalf("VegetaLine = %s, want %s", got, want)
+	}
+}
+
+func TestK6Metric(t *testing.T) {
+	metric := testResult().K6Metric()
+
+	want := map[string]float64{
+		"http_req_blocked":         5,
+		"http_req_connecting":      10,
+		"http_req_tls_handshaking": 20,
+		"http_req_waiting":         40,
+		"http_req_receiving":       25,
+		"http_req_duration":        65,
+	}
+
+	if len(metric) != len(want) {
+		t.Fatalf("K6Metric has %d metrics, want %d", len(metric), len(want))
+	}
+
+	for k, v := range want {
+		if got := metric[k]; got != v {
+			t.Fatalf("%s = %v, want %v", k, got, v)
+		}
+	}
+}
+
+func TestWriteGnuplot(t *testing.T) {
+	slow := testResult()
+	slow.ServerProcessing = 1500 * time.Microsecond
+
+	var buf bytes.Buffer
+	if err := WriteGnuplot(&buf, []*Result{testResult(), slow}); err != nil {
+		t.Fatal("WriteGnuplot failed:", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []string{
+		"# index dns tcp tls server transfer total (ms)",
+		"0 5.000 10.000 20.000 40.000 25.000 100.000",
+		"1 5.000 10.000 20.000 1.500 25.000 100.000",
+	}
+
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("line #%d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestTemplateData(t *testing.T) {
+	rows := testResult().TemplateData()
+
+	want := []TemplateRow{
+		{Name: "DNSLookup", Duration: 5 * time.Millisecond, Percent: 5},
+		{Name: "TCPConnection", Duration: 10 * time.Millisecond, Percent: 10},
+		{Name: "TLSHandshake", Duration: 20 * time.Millisecond, Percent: 20, IsTLS: true},
+		{Name: "ServerProcessing", Duration: 40 * time.Millisecond, Percent: 40},
+		{Name: "ContentTransfer", Duration: 25 * time.Millisecond, Percent: 25},
+	}
+
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Fatalf("#%d row = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+
+	tmpl := template.Must(template.New("").Parse(
+		`{{range .}}{{.Name}}{{if .IsTLS}} (TLS){{end}} {{.Percent}}%;{{end}}`))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, rows); err != nil {
+		t.Fatal("Execute failed:", err)
+	}
+
+	wantText := "DNSLookup 5%;TCPConnection 10%;TLSHandshake (TLS) 20%;ServerProcessing 40%;ContentTransfer 25%;"
+	if got := buf.String(); got != wantText {
+		t.Fatalf("template = %q, want %q", got, wantText)
+	}
+}
+
+func TestWriteLokiLine(t *testing.T) {
+	labels := map[string]string{
+		"job": "httpstat",
+		"env": "prod",
+	}
+	ts := time.Unix(1628000000, 123)
+
+	var buf bytes.Buffer
+	if err := WriteLokiLine(&buf, labels, testResult(), ts); err != nil {
+		t.Fatal("WriteLokiLine failed:", err)
+	}
+
+	want := `{env="prod", job="httpstat"} 1628000000000000123 ` +
+		"dns_lookup=5ms tcp_connection=10ms tls_handshake=20ms server_processing=40ms content_transfer=25ms total=100ms\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("WriteLokiLine = %q, want %q", got, want)
+	}
+}
@@ -2,4 +2,8 @@ module github.com/jon4hz/go-httpstat
 
 go 1.17
 
-require golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
+require (
+	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
+	golang.org/x/sys v0.1.0
+	google.golang.org/protobuf v1.31.0
+)
@@ -3,8 +3,11 @@
 package httpstat
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"fmt"
+	"net"
 	"net/http/httptrace"
 	"sync"
 	"time"
@@ -19,6 +22,10 @@ type Result struct {
 	ServerProcessing time.Duration
 	contentTransfer  time.Duration
 
+	// HeadersComplete is the duration from first response byte until the
+	// response headers are parsed. It's set by SetResponseReady.
+	HeadersComplete time.Duration
+
 	// The followings are timeline of request
 	NameLookup    time.Duration
 	Connect       time.Duration
@@ -41,6 +48,7 @@ type Result struct {
 	tlsDone       time.Time
 	serverStart   time.Time
 	serverDone    time.Time
+	responseReady time.Time // need to be provided from outside
 	transferStart time.Time
 	transferDone  time.Time // need to be provided from outside
 
@@ -50,6 +58,55 @@ type Result struct {
 	// isReused is true when connection is reused (keep-alive)
 	isReused bool
 
+	// idleTime is how long the reused connection was idle
+	idleTime time.Duration
+
+	// tlsErr is the error of TLS handshake, if any
+	tlsErr error
+
+	// tlsVersion, tlsCipherSuite and tlsServerName are captured from
+	// TLS handshake
+	tlsVersion     uint16
+	tlsCipherSuite uint16
+	tlsServerName  string
+
+	// didResume is true when TLS session is resumed
+	didResume bool
+
+	// remoteAddr is the address of the connection
+	remoteAddr string
+
+	// rttKernel and retransmits are read from TCP_INFO or set by SetTCPInfo
+	hasTCPInfo  bool
+	rttKernel   time.Duration
+	retransmits int
+
+	// conn is the connection from GotConn, kept until the first
+	// response byte to read TCP_INFO of it
+	conn net.Conn
+
+	// isFinalized is true when End is called
+	isFinalized bool
+
+	// noNetwork is true when End is called but no network activity was
+	// traced, e.g. a RoundTripper returned a canned response
+	noNetwork bool
+
+	// viaProxy is true when request is sent through a proxy
+	viaProxy bool
+
+	// redirects is the number of redirects followed before the request
+	redirects int
+
+	// hasPath is true when viaProxy and redirects are detected by Transport
+	hasPath bool
+
+	// route is the route template set by SetRoute
+	route string
+
+	// checkpoints are recorded by the body returned from WrapBody
+	checkpoints []Checkpoint
+
 	mu *sync.Mutex
 }
 
@@ -75,25 +132,247 @@ func WithHTTPStat(ctx context.Context, r *Result) context.Context {
 	return withClientTrace(ctx, r)
 }
 
+type skipKey struct{}
+
+// WithoutHTTPStat returns a context which opts the request out of being
+// measured by Transport, e.g. for health checks or high frequency calls.
+func WithoutHTTPStat(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipKey{}, true)
+}
+
+func skipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipKey{}).(bool)
+	return skip
+}
+
 // End sets the time when reading response is done.
-// This must be called after reading response body.
+// This must be called after reading response body. Until then content
+// transfer and total, and everything derived from them, are zero.
 func (r *Result) End(t time.Time) {
 	r.transferDone = t
+	r.isFinalized = true
 
 	// This means result is empty (it does nothing).
 	// Skip setting value(contentTransfer and total will be zero).
 	if r.dnsStart.IsZero() {
+		r.noNetwork = true
 		return
 	}
 
-	r.contentTransfer = r.transferDone.Sub(r.transferStart)
+	// When no response is received (e.g. TLS handshake failed),
+	// there is no content transfer.
+	if !r.transferStart.IsZero() {
+		r.contentTransfer = r.transferDone.Sub(r.transferStart)
+	}
 	r.total = r.transferDone.Sub(r.dnsStart)
 }
 
+// NoNetwork returns true when End has been called but no network activity
+// was traced. That happens when a RoundTripper short-circuits the request,
+// e.g. a mock returning canned responses. All durations are zero then.
+func (r *Result) NoNetwork() bool {
+	return r.noNetwork
+}
+
+// SetResponseReady sets the time when the response became available, that
+// is when client.Do returned, and computes HeadersComplete from it.
+// It must be called right after client.Do.
+func (r *Result) SetResponseReady(t time.Time) {
+	r.responseReady = t
+
+	// First response byte is not traced.
+	if r.serverDone.IsZero() {
+		return
+	}
+
+	r.HeadersComplete = r.responseReady.Sub(r.serverDone)
+}
+
+// IsFinalized returns true when End has been called. Until then, content
+// transfer and total are not measured and stay zero.
+func (r *Result) IsFinalized() bool {
+	return r.isFinalized
+}
+
+// TLSError returns the error of TLS handshake, if any.
+func (r *Result) TLSError() error {
+	return r.tlsErr
+}
+
+// TLSVersion returns the TLS version negotiated in TLS handshake (e.g.
+// tls.VersionTLS13). It's zero when no handshake is traced.
+func (r *Result) TLSVersion() uint16 {
+	return r.tlsVersion
+}
+
+// TLSCipherSuite returns the cipher suite negotiated in TLS handshake.
+// It's zero when no handshake is traced.
+func (r *Result) TLSCipherSuite() uint16 {
+	return r.tlsCipherSuite
+}
+
+// DidResume returns true when TLS handshake resumed a previous session
+// (e.g. with a session ticket).
+func (r *Result) DidResume() bool {
+	return r.didResume
+}
+
+// RemoteAddr returns the address of the connection the request was sent
+// on. With a proxy, it's the address of the proxy.
+func (r *Result) RemoteAddr() string {
+	return r.remoteAddr
+}
+
+// ConnectedButTLSFailed returns true when TCP connection succeeded but TLS
+// handshake failed afterwards (e.g. bad certificate). In that case
+// TLSHandshake is measured until the failure and Pretransfer is zero.
+func (r *Result) ConnectedButTLSFailed() bool {
+	return r.TCPConnection > 0 && r.tlsErr != nil
+}
+
+// StartedAt returns the time when the request started. It is the start
+// of the DNS lookup, or of the first hook called when there was none.
+func (r *Result) StartedAt() time.Time {
+	return r.dnsStart
+}
+
+// EndedAt returns the time given to End.
+func (r *Result) EndedAt() time.Time {
+	return r.transferDone
+}
+
+// Slack returns how much time was left until the deadline of ctx when
+// the request ended, i.e. the deadline minus EndedAt. It's negative when
+// the deadline was exceeded, even if the request itself succeeded. It
+// returns zero when ctx has no deadline or End has not been called.
+func (r *Result) Slack(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok || r.transferDone.IsZero() {
+		return 0
+	}
+	return deadline.Sub(r.transferDone)
+}
+
+// IsTLS returns true when the connection seems to use TLS.
+func (r *Result) IsTLS() bool {
+	return r.isTLS
+}
+
+// IsReused returns true when the connection is reused (keep-alive or a
+// HTTP/2 stream on an existing connection).
+func (r *Result) IsReused() bool {
+	return r.isReused
+}
+
+// IdleTime returns how long the reused connection was idle before the
+// request. It's zero for a new connection.
+func (r *Result) IdleTime() time.Duration {
+	return r.idleTime
+}
+
+// ConnectionSetup returns the time to establish the connection, that is
+// TCPConnection plus TLSHandshake. DNS lookup is excluded. With HTTP/2 it
+// is the canonical setup cost, since only the first stream of a
+// connection pays it. For a reused connection it returns zero.
+func (r *Result) ConnectionSetup() time.Duration {
+	if r.isReused {
+		return 0
+	}
+	return r.TCPConnection + r.TLSHandshake
+}
+
+// SetupToTransferRatio returns the time spent on DNS lookup, TCP
+// connection and TLS handshake divided by the time of content transfer
+// (at least 1ns). A high ratio means connection pooling would help a lot,
+// a low ratio means content transfer dominates.
+func (r *Result) SetupToTransferRatio() float64 {
+	setup := r.DNSLookup + r.TCPConnection + r.TLSHandshake
+
+	transfer := r.contentTransfer
+	if transfer < time.Nanosecond {
+		transfer = time.Nanosecond
+	}
+
+	return float64(setup) / float64(transfer)
+}
+
+// notMeasured is printed by String instead of durations which need End.
+const notMeasured = "(transfer not measured)"
+
+// String returns a human readable report of the result. When End has not
+// been called, content transfer and total are reported as not measured.
+func (r *Result) String() string {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "DNS lookup:        %4d ms\n", int(r.DNSLookup/time.Millisecond))
+	fmt.Fprintf(&buf, "TCP connection:    %4d ms\n", int(r.TCPConnection/time.Millisecond))
+	fmt.Fprintf(&buf, "TLS handshake:     %4d ms\n", int(r.TLSHandshake/time.Millisecond))
+	fmt.Fprintf(&buf, "Server processing: %4d ms\n", int(r.ServerProcessing/time.Millisecond))
+	if r.isFinalized {
+		fmt.Fprintf(&buf, "Content transfer:  %4d ms\n\n", int(r.contentTransfer/time.Millisecond))
+	} else {
+		fmt.Fprintf(&buf, "Content transfer:  %s\n\n", notMeasured)
+	}
+
+	fmt.Fprintf(&buf, "Name Lookup:    %4d ms\n", int(r.NameLookup/time.Millisecond))
+	fmt.Fprintf(&buf, "Connect:        %4d ms\n", int(r.Connect/time.Millisecond))
+	fmt.Fprintf(&buf, "Pre Transfer:   %4d ms\n", int(r.Pretransfer/time.Millisecond))
+	fmt.Fprintf(&buf, "Start Transfer: %4d ms\n", int(r.StartTransfer/time.Millisecond))
+	if r.isFinalized {
+		fmt.Fprintf(&buf, "Total:          %4d ms\n", int(r.total/time.Millisecond))
+	} else {
+		fmt.Fprintf(&buf, "Total:          %s\n", notMeasured)
+	}
+
+	return buf.String()
+}
+
+// Explain returns the report of String followed by details of the
+// connection the request was sent on. Path is only included when the
+// Result comes from Transport, which is the only place it's detected.
+func (r *Result) Explain() string {
+	var buf bytes.Buffer
+	buf.WriteString(r.String())
+	buf.WriteString("\n")
+
+	if r.isReused {
+		fmt.Fprintf(&buf, "Connection:     reused (idle %s)\n", r.idleTime)
+	} else {
+		fmt.Fprintf(&buf, "Connection:     new\n")
+	}
+
+	switch {
+	case r.tlsErr != nil:
+		fmt.Fprintf(&buf, "TLS:            failed (%s)\n", r.tlsErr)
+	case r.didResume:
+		fmt.Fprintf(&buf, "TLS:            resumed\n")
+	case r.isTLS:
+		fmt.Fprintf(&buf, "TLS:            full handshake\n")
+	default:
+		fmt.Fprintf(&buf, "TLS:            none\n")
+	}
+
+	if r.hasPath {
+		fmt.Fprintf(&buf, "Path:           %s\n", r.Path())
+	}
+	if r.remoteAddr != "" {
+		fmt.Fprintf(&buf, "Remote address: %s\n", r.remoteAddr)
+	}
+
+	if r.hasTCPInfo {
+		fmt.Fprintf(&buf, "Kernel RTT:     %s (%d retransmits)\n", r.rttKernel, r.retransmits)
+	}
+
+	return buf.String()
+}
+
 // ContentTransfer returns the duration of content transfer time.
 // It is from first response byte to the given time. The time must
 // be time after read body (go-httpstat can not detect that time).
 func (r *Result) ContentTransfer(t time.Time) time.Duration {
+	if r.serverDone.IsZero() {
+		return 0
+	}
 	return t.Sub(r.serverDone)
 }
 
@@ -101,6 +380,9 @@ func (r *Result) ContentTransfer(t time.Time) time.Duration {
 // It is from dns lookup start time to the given time. The
 // time must be time after read body (go-httpstat can not detect that time).
 func (r *Result) Total(t time.Time) time.Duration {
+	if r.dnsStart.IsZero() {
+		return 0
+	}
 	return t.Sub(r.dnsStart)
 }
 
@@ -155,13 +437,24 @@ func withClientTrace(ctx context.Context, r *Result) context.Context {
 			r.tlsStart = time.Now()
 		},
 
-		TLSHandshakeDone: func(_ tls.ConnectionState, _ error) {
+		TLSHandshakeDone: func(cs tls.ConnectionState, err error) {
 			r.mu.Lock()
 			defer r.mu.Unlock()
 
 			r.tlsDone = time.Now()
 
+			r.tlsVersion = cs.Version
+			r.tlsCipherSuite = cs.CipherSuite
+			r.tlsServerName = cs.ServerName
+			r.didResume = cs.DidResume
+
+			// When handshake fails, it's measured until the failure
+			// but transfer is never ready.
 			r.TLSHandshake = r.tlsDone.Sub(r.tlsStart)
+			if err != nil {
+				r.tlsErr = err
+				return
+			}
 			r.Pretransfer = r.tlsDone.Sub(r.dnsStart)
 		},
 
@@ -169,10 +462,16 @@ func withClientTrace(ctx context.Context, r *Result) context.Context {
 			r.mu.Lock()
 			defer r.mu.Unlock()
 
+			if i.Conn != nil {
+				r.remoteAddr = i.Conn.RemoteAddr().String()
+				r.conn = i.Conn
+			}
+
 			// Handle when keep alive is used and connection is reused.
 			// DNSStart(Done) and ConnectStart(Done) is skipped
 			if i.Reused {
 				r.isReused = true
+				r.idleTime = i.IdleTime
 			}
 		},
 
@@ -223,6 +522,17 @@ func withClientTrace(ctx context.Context, r *Result) context.Context {
 			r.StartTransfer = r.serverDone.Sub(r.dnsStart)
 
 			r.transferStart = r.serverDone
+
+			// Read TCP_INFO of the connection this request is sent on,
+			// whether it's new or reused. It's only supported on Linux.
+			if r.conn != nil {
+				if rtt, retransmits, err := readTCPInfo(r.conn); err == nil {
+					r.hasTCPInfo = true
+					r.rttKernel = rtt
+					r.retransmits = retransmits
+				}
+				r.conn = nil
+			}
 		},
 	})
 }
@@ -1,11 +1,14 @@
 package httpstat
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -249,3 +252,265 @@ func getStat(url string) error {
 	fmt.Println(result.total)
 	return nil
 }
+
+func TestString_NotFinalized(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	var result Result
+	req := NewRequest(t, ts.URL, &result)
+
+	res, err := DefaultClient().Do(req)
+	if err != nil {
+		t.Fatal("client.Do failed:", err)
+	}
+
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+	res.Body.Close()
+
+	if result.IsFinalized() {
+		t.Fatal("IsFinalized should be false")
+	}
+
+	if got := result.String(); !strings.Contains(got, notMeasured) {
+		t.Fatalf("String should warn %q, got:\n%s", notMeasured, got)
+	}
+
+	durations := []time.Duration{
+		result.TCPConnection,
+		result.ServerProcessing,
+		result.Connect,
+		result.StartTransfer,
+	}
+
+	for i, d := range durations {
+		if d <= 0*time.Millisecond {
+			t.Fatalf("#%d expect %d to be non-zero", i, d)
+		}
+	}
+
+	result.End(time.Now())
+	if got := result.String(); strings.Contains(got, notMeasured) {
+		t.Fatalf("String should not warn after End, got:\n%s", got)
+	}
+}
+
+func TestConnectionSetup(t *testing.T) {
+	fresh := &Result{
+		DNSLookup:     5 * time.Millisecond,
+		TCPConnection: 10 * time.Millisecond,
+		TLSHandshake:  20 * time.Millisecond,
+		isTLS:         true,
+	}
+
+	if got, want := fresh.ConnectionSetup(), 30*time.Millisecond; got != want {
+		t.Fatalf("ConnectionSetup = %s, want %s", got, want)
+	}
+
+	reused := &Result{
+		ServerProcessing: 10 * time.Millisecond,
+		isTLS:            true,
+		isReused:         true,
+	}
+
+	if got, want := reused.ConnectionSetup(), time.Duration(0); got != want {
+		t.Fatalf("ConnectionSetup = %s, want %s", got, want)
+	}
+}
+
+func TestHTTPStat_BadCertificate(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	var result Result
+	req := NewRequest(t, ts.URL, &result)
+
+	// Certificate of test server is not trusted by default transport.
+	if _, err := DefaultClient().Do(req); err == nil {
+		t.Fatal("client.Do should fail")
+	}
+	result.End(time.Now())
+
+	if !result.ConnectedButTLSFailed() {
+		t.Fatal("ConnectedButTLSFailed should be true")
+	}
+
+	if result.TLSError() == nil {
+		t.Fatal("TLSError should not be nil")
+	}
+
+	if result.TCPConnection <= 0*time.Millisecond {
+		t.Fatalf("expect TCPConnection %d to be non-zero", result.TCPConnection)
+	}
+
+	if result.TLSHandshake <= 0*time.Millisecond {
+		t.Fatalf("expect TLSHandshake %d to be non-zero", result.TLSHandshake)
+	}
+
+	if got, want := result.Pretransfer, 0*time.Millisecond; got != want {
+		t.Fatalf("Pretransfer = %d, want %d", got, want)
+	}
+
+	if got, want := result.contentTransfer, 0*time.Millisecond; got != want {
+		t.Fatalf("ContentTransfer = %d, want %d", got, want)
+	}
+
+	if result.NoNetwork() {
+		t.Fatal("NoNetwork should be false")
+	}
+}
+
+func TestSetResponseReady(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 100; i++ {
+			w.Header().Add(fmt.Sprintf("X-Header-%d", i), strings.Repeat("x", 100))
+		}
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	var result Result
+	req := NewRequest(t, ts.URL, &result)
+
+	res, err := DefaultClient().Do(req)
+	if err != nil {
+		t.Fatal("client.Do failed:", err)
+	}
+	ready := time.Now()
+	result.SetResponseReady(ready)
+
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+	res.Body.Close()
+	result.End(time.Now())
+
+	if got, want := result.HeadersComplete, ready.Sub(result.serverDone); got != want {
+		t.Fatalf("HeadersComplete = %d, want %d", got, want)
+	}
+
+	if result.HeadersComplete <= 0*time.Millisecond {
+		t.Fatalf("expect HeadersComplete %d to be non-zero", result.HeadersComplete)
+	}
+
+	if result.HeadersComplete > result.contentTransfer {
+		t.Fatalf("HeadersComplete %d should not exceed ContentTransfer %d", result.HeadersComplete, result.contentTransfer)
+	}
+}
+
+// roundTripFunc is a RoundTripper which returns canned responses.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHTTPStat_NoNetwork(t *testing.T) {
+	var result Result
+	req := NewRequest(t, TestDomainHTTPS, &result)
+
+	client := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("canned")),
+				Request:    req,
+			}, nil
+		}),
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal("client.Do failed:", err)
+	}
+
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+	res.Body.Close()
+	end := time.Now()
+	result.End(end)
+
+	if !result.NoNetwork() {
+		t.Fatal("NoNetwork should be true")
+	}
+
+	if !result.IsFinalized() {
+		t.Fatal("IsFinalized should be true")
+	}
+
+	durations := []time.Duration{
+		result.total,
+		result.contentTransfer,
+		result.Total(end),
+		result.ContentTransfer(end),
+	}
+
+	for i, d := range durations {
+		if got, want := d, 0*time.Millisecond; got != want {
+			t.Fatalf("#%d expect %d to be eq %d", i, got, want)
+		}
+	}
+}
+
+func TestSetupToTransferRatio(t *testing.T) {
+	setupHeavy := &Result{
+		DNSLookup:       20 * time.Millisecond,
+		TCPConnection:   30 * time.Millisecond,
+		TLSHandshake:    50 * time.Millisecond,
+		contentTransfer: 10 * time.Millisecond,
+	}
+
+	if got, want := setupHeavy.SetupToTransferRatio(), 10.0; got != want {
+		t.Fatalf("SetupToTransferRatio = %v, want %v", got, want)
+	}
+
+	transferHeavy := &Result{
+		DNSLookup:       time.Millisecond,
+		TCPConnection:   2 * time.Millisecond,
+		TLSHandshake:    2 * time.Millisecond,
+		contentTransfer: 500 * time.Millisecond,
+	}
+
+	if got, want := transferHeavy.SetupToTransferRatio(), 0.01; got != want {
+		t.Fatalf("SetupToTransferRatio = %v, want %v", got, want)
+	}
+
+	// Content transfer is clamped to 1ns.
+	noTransfer := &Result{TCPConnection: time.Microsecond}
+	if got, want := noTransfer.SetupToTransferRatio(), 1000.0; got != want {
+		t.Fatalf("SetupToTransferRatio = %v, want %v", got, want)
+	}
+}
+
+func TestSlack(t *testing.T) {
+	start := time.Now()
+
+	var result Result
+	result.dnsStart = start
+	result.End(start.Add(100 * time.Millisecond))
+
+	generous, cancel := context.WithDeadline(context.Background(), start.Add(time.Second))
+	defer cancel()
+
+	if got, want := result.Slack(generous), 900*time.Millisecond; got != want {
+		t.Fatalf("Slack = %s, want %s", got, want)
+	}
+
+	tight, cancel := context.WithDeadline(context.Background(), start.Add(80*time.Millisecond))
+	defer cancel()
+
+	if got, want := result.Slack(tight), -20*time.Millisecond; got != want {
+		t.Fatalf("Slack = %s, want %s", got, want)
+	}
+
+	if got, want := result.Slack(context.Background()), time.Duration(0); got != want {
+		t.Fatalf("Slack without deadline = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Package httpstatpb provides the protobuf representation of
+// httpstat.Result, e.g. for streaming measurements over gRPC.
+package httpstatpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative httpstat.proto
+
+import (
+	"time"
+
+	httpstat "github.com/jon4hz/go-httpstat"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto converts r to its protobuf message.
+func ToProto(r *httpstat.Result) *Result {
+	s := r.Snapshot()
+	return &Result{
+		DnsLookup:        durationpb.New(s.DNSLookup),
+		TcpConnection:    durationpb.New(s.TCPConnection),
+		TlsHandshake:     durationpb.New(s.TLSHandshake),
+		ServerProcessing: durationpb.New(s.ServerProcessing),
+		ContentTransfer:  durationpb.New(s.ContentTransfer),
+		HeadersComplete:  durationpb.New(s.HeadersComplete),
+
+		NameLookup:    durationpb.New(s.NameLookup),
+		Connect:       durationpb.New(s.Connect),
+		Pretransfer:   durationpb.New(s.Pretransfer),
+		StartTransfer: durationpb.New(s.StartTransfer),
+		Total:         durationpb.New(s.Total),
+
+		StartedAt: timestamp(s.StartedAt),
+		EndedAt:   timestamp(s.EndedAt),
+
+		IsTls:       s.IsTLS,
+		IsReused:    s.IsReused,
+		IsFinalized: s.IsFinalized,
+		ViaProxy:    s.ViaProxy,
+		Redirects:   int32(s.Redirects),
+		Route:       s.Route,
+
+		TlsVersion:     uint32(s.TLSVersion),
+		TlsCipherSuite: uint32(s.TLSCipherSuite),
+		TlsServerName:  s.TLSServerName,
+		TlsError:       s.TLSError,
+		RemoteAddr:     s.RemoteAddr,
+
+		IdleTime:  durationpb.New(s.IdleTime),
+		NoNetwork: s.NoNetwork,
+		DidResume: s.DidResume,
+
+		KernelRtt:   duration(s.KernelRTT),
+		Retransmits: int32(s.Retransmits),
+
+		HasPath: s.HasPath,
+	}
+}
+
+// FromProto converts m back to a httpstat.Result.
+func FromProto(m *Result) *httpstat.Result {
+	return httpstat.FromSnapshot(httpstat.Snapshot{
+		DNSLookup:        m.GetDnsLookup().AsDuration(),
+		TCPConnection:    m.GetTcpConnection().AsDuration(),
+		TLSHandshake:     m.GetTlsHandshake().AsDuration(),
+		ServerProcessing: m.GetServerProcessing().AsDuration(),
+		ContentTransfer:  m.GetContentTransfer().AsDuration(),
+		HeadersComplete:  m.GetHeadersComplete().AsDuration(),
+
+		NameLookup:    m.GetNameLookup().AsDuration(),
+		Connect:       m.GetConnect().AsDuration(),
+		Pretransfer:   m.GetPretransfer().AsDuration(),
+		StartTransfer: m.GetStartTransfer().AsDuration(),
+		Total:         m.GetTotal().AsDuration(),
+
+		StartedAt: fromTimestamp(m.GetStartedAt()),
+		EndedAt:   fromTimestamp(m.GetEndedAt()),
+
+		IsTLS:       m.GetIsTls(),
+		IsReused:    m.GetIsReused(),
+		IsFinalized: m.GetIsFinalized(),
+		ViaProxy:    m.GetViaProxy(),
+		Redirects:   int(m.GetRedirects()),
+		Route:       m.GetRoute(),
+
+		TLSVersion:     uint16(m.GetTlsVersion()),
+		TLSCipherSuite: uint16(m.GetTlsCipherSuite()),
+		TLSServerName:  m.GetTlsServerName(),
+		TLSError:       m.GetTlsError(),
+		RemoteAddr:     m.GetRemoteAddr(),
+
+		IdleTime:  m.GetIdleTime().AsDuration(),
+		NoNetwork: m.GetNoNetwork(),
+		DidResume: m.GetDidResume(),
+
+		KernelRTT:   m.GetKernelRtt().AsDuration(),
+		Retransmits: int(m.GetRetransmits()),
+
+		HasPath: m.GetHasPath(),
+	})
+}
+
+// duration returns nil for zero, so optional durations are not set.
+func duration(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
+
+// timestamp returns nil for the zero time, so it stays zero in FromProto.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
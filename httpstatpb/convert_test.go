@@ -0,0 +1,81 @@
+package httpstatpb
+
+import (
+	"crypto/tls"
+	"reflect"
+	"testing"
+	"time"
+
+	httpstat "github.com/jon4hz/go-httpstat"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	startedAt := time.Date(2021, 8, 1, 12, 0, 0, 123, time.UTC)
+	want := httpstat.Snapshot{
+		DNSLookup:        5 * time.Millisecond,
+		TCPConnection:    10 * time.Millisecond,
+		TLSHandshake:     20 * time.Millisecond,
+		ServerProcessing: 40 * time.Millisecond,
+		ContentTransfer:  25 * time.Millisecond,
+		HeadersComplete:  time.Millisecond,
+
+		NameLookup:    5 * time.Millisecond,
+		Connect:       15 * time.Millisecond,
+		Pretransfer:   35 * time.Millisecond,
+		StartTransfer: 75 * time.Millisecond,
+		Total:         100 * time.Millisecond,
+
+		StartedAt: startedAt,
+		EndedAt:   startedAt.Add(100 * time.Millisecond),
+
+		IsTLS:       true,
+		IsReused:    true,
+		IsFinalized: true,
+		IdleTime:    3 * time.Second,
+		HasPath:     true,
+		ViaProxy:    true,
+		Redirects:   2,
+		Route:       "/users/{id}",
+
+		TLSVersion:     tls.VersionTLS13,
+		TLSCipherSuite: tls.TLS_AES_128_GCM_SHA256,
+		TLSServerName:  "example.com",
+		DidResume:      true,
+		TLSError:       "remote error: tls: bad certificate",
+		RemoteAddr:     "93.184.216.34:443",
+
+		KernelRTT:   12 * time.Millisecond,
+		Retransmits: 3,
+	}
+
+	b, err := proto.Marshal(ToProto(httpstat.FromSnapshot(want)))
+	if err != nil {
+		t.Fatal("proto.Marshal failed:", err)
+	}
+
+	var m Result
+	if err := proto.Unmarshal(b, &m); err != nil {
+		t.Fatal("proto.Unmarshal failed:", err)
+	}
+
+	if got := FromProto(&m).Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestRoundTrip_Zero(t *testing.T) {
+	b, err := proto.Marshal(ToProto(&httpstat.Result{}))
+	if err != nil {
+		t.Fatal("proto.Marshal failed:", err)
+	}
+
+	var m Result
+	if err := proto.Unmarshal(b, &m); err != nil {
+		t.Fatal("proto.Unmarshal failed:", err)
+	}
+
+	if got, want := FromProto(&m).Snapshot(), (httpstat.Snapshot{}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip = %+v, want %+v", got, want)
+	}
+}
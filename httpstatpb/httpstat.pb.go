@@ -0,0 +1,490 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: httpstat.proto
+
+package httpstatpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Result is a measurement of a single HTTP request.
+type Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Duration of each phase.
+	DnsLookup        *durationpb.Duration `protobuf:"bytes,1,opt,name=dns_lookup,json=dnsLookup,proto3" json:"dns_lookup,omitempty"`
+	TcpConnection    *durationpb.Duration `protobuf:"bytes,2,opt,name=tcp_connection,json=tcpConnection,proto3" json:"tcp_connection,omitempty"`
+	TlsHandshake     *durationpb.Duration `protobuf:"bytes,3,opt,name=tls_handshake,json=tlsHandshake,proto3" json:"tls_handshake,omitempty"`
+	ServerProcessing *durationpb.Duration `protobuf:"bytes,4,opt,name=server_processing,json=serverProcessing,proto3" json:"server_processing,omitempty"`
+	ContentTransfer  *durationpb.Duration `protobuf:"bytes,5,opt,name=content_transfer,json=contentTransfer,proto3" json:"content_transfer,omitempty"`
+	HeadersComplete  *durationpb.Duration `protobuf:"bytes,6,opt,name=headers_complete,json=headersComplete,proto3" json:"headers_complete,omitempty"`
+	// Timeline of the request.
+	NameLookup    *durationpb.Duration   `protobuf:"bytes,7,opt,name=name_lookup,json=nameLookup,proto3" json:"name_lookup,omitempty"`
+	Connect       *durationpb.Duration   `protobuf:"bytes,8,opt,name=connect,proto3" json:"connect,omitempty"`
+	Pretransfer   *durationpb.Duration   `protobuf:"bytes,9,opt,name=pretransfer,proto3" json:"pretransfer,omitempty"`
+	StartTransfer *durationpb.Duration   `protobuf:"bytes,10,opt,name=start_transfer,json=startTransfer,proto3" json:"start_transfer,omitempty"`
+	Total         *durationpb.Duration   `protobuf:"bytes,11,opt,name=total,proto3" json:"total,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	IsTls         bool                   `protobuf:"varint,14,opt,name=is_tls,json=isTls,proto3" json:"is_tls,omitempty"`
+	IsReused      bool                   `protobuf:"varint,15,opt,name=is_reused,json=isReused,proto3" json:"is_reused,omitempty"`
+	IsFinalized   bool                   `protobuf:"varint,16,opt,name=is_finalized,json=isFinalized,proto3" json:"is_finalized,omitempty"`
+	ViaProxy      bool                   `protobuf:"varint,17,opt,name=via_proxy,json=viaProxy,proto3" json:"via_proxy,omitempty"`
+	Redirects     int32                  `protobuf:"varint,18,opt,name=redirects,proto3" json:"redirects,omitempty"`
+	Route         string                 `protobuf:"bytes,19,opt,name=route,proto3" json:"route,omitempty"`
+	// TLS connection state and address of the connection.
+	TlsVersion     uint32               `protobuf:"varint,20,opt,name=tls_version,json=tlsVersion,proto3" json:"tls_version,omitempty"`
+	TlsCipherSuite uint32               `protobuf:"varint,21,opt,name=tls_cipher_suite,json=tlsCipherSuite,proto3" json:"tls_cipher_suite,omitempty"`
+	TlsServerName  string               `protobuf:"bytes,22,opt,name=tls_server_name,json=tlsServerName,proto3" json:"tls_server_name,omitempty"`
+	TlsError       string               `protobuf:"bytes,23,opt,name=tls_error,json=tlsError,proto3" json:"tls_error,omitempty"`
+	RemoteAddr     string               `protobuf:"bytes,24,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	IdleTime       *durationpb.Duration `protobuf:"bytes,25,opt,name=idle_time,json=idleTime,proto3" json:"idle_time,omitempty"`
+	NoNetwork      bool                 `protobuf:"varint,26,opt,name=no_network,json=noNetwork,proto3" json:"no_network,omitempty"`
+	DidResume      bool                 `protobuf:"varint,27,opt,name=did_resume,json=didResume,proto3" json:"did_resume,omitempty"`
+	// Only set when TCP_INFO is recorded.
+	KernelRtt   *durationpb.Duration `protobuf:"bytes,28,opt,name=kernel_rtt,json=kernelRtt,proto3" json:"kernel_rtt,omitempty"`
+	Retransmits int32                `protobuf:"varint,29,opt,name=retransmits,proto3" json:"retransmits,omitempty"`
+	// True when via_proxy and redirects are detected.
+	HasPath bool `protobuf:"varint,30,opt,name=has_path,json=hasPath,proto3" json:"has_path,omitempty"`
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_httpstat_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_httpstat_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_httpstat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Result) GetDnsLookup() *durationpb.Duration {
+	if x != nil {
+		return x.DnsLookup
+	}
+	return nil
+}
+
+func (x *Result) GetTcpConnection() *durationpb.Duration {
+	if x != nil {
+		return x.TcpConnection
+	}
+	return nil
+}
+
+func (x *Result) GetTlsHandshake() *durationpb.Duration {
+	if x != nil {
+		return x.TlsHandshake
+	}
+	return nil
+}
+
+func (x *Result) GetServerProcessing() *durationpb.Duration {
+	if x != nil {
+		return x.ServerProcessing
+	}
+	return nil
+}
+
+func (x *Result) GetContentTransfer() *durationpb.Duration {
+	if x != nil {
+		return x.ContentTransfer
+	}
+	return nil
+}
+
+func (x *Result) GetHeadersComplete() *durationpb.Duration {
+	if x != nil {
+		return x.HeadersComplete
+	}
+	return nil
+}
+
+func (x *Result) GetNameLookup() *durationpb.Duration {
+	if x != nil {
+		return x.NameLookup
+	}
+	return nil
+}
+
+func (x *Result) GetConnect() *durationpb.Duration {
+	if x != nil {
+		return x.Connect
+	}
+	return nil
+}
+
+func (x *Result) GetPretransfer() *durationpb.Duration {
+	if x != nil {
+		return x.Pretransfer
+	}
+	return nil
+}
+
+func (x *Result) GetStartTransfer() *durationpb.Duration {
+	if x != nil {
+		return x.StartTransfer
+	}
+	return nil
+}
+
+func (x *Result) GetTotal() *durationpb.Duration {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *Result) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Result) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *Result) GetIsTls() bool {
+	if x != nil {
+		return x.IsTls
+	}
+	return false
+}
+
+func (x *Result) GetIsReused() bool {
+	if x != nil {
+		return x.IsReused
+	}
+	return false
+}
+
+func (x *Result) GetIsFinalized() bool {
+	if x != nil {
+		return x.IsFinalized
+	}
+	return false
+}
+
+func (x *Result) GetViaProxy() bool {
+	if x != nil {
+		return x.ViaProxy
+	}
+	return false
+}
+
+func (x *Result) GetRedirects() int32 {
+	if x != nil {
+		return x.Redirects
+	}
+	return 0
+}
+
+func (x *Result) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *Result) GetTlsVersion() uint32 {
+	if x != nil {
+		return x.TlsVersion
+	}
+	return 0
+}
+
+func (x *Result) GetTlsCipherSuite() uint32 {
+	if x != nil {
+		return x.TlsCipherSuite
+	}
+	return 0
+}
+
+func (x *Result) GetTlsServerName() string {
+	if x != nil {
+		return x.TlsServerName
+	}
+	return ""
+}
+
+func (x *Result) GetTlsError() string {
+	if x != nil {
+		return x.TlsError
+	}
+	return ""
+}
+
+func (x *Result) GetRemoteAddr() string {
+	if x != nil {
+		return x.RemoteAddr
+	}
+	return ""
+}
+
+func (x *Result) GetIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.IdleTime
+	}
+	return nil
+}
+
+func (x *Result) GetNoNetwork() bool {
+	if x != nil {
+		return x.NoNetwork
+	}
+	return false
+}
+
+func (x *Result) GetDidResume() bool {
+	if x != nil {
+		return x.DidResume
+	}
+	return false
+}
+
+func (x *Result) GetKernelRtt() *durationpb.Duration {
+	if x != nil {
+		return x.KernelRtt
+	}
+	return nil
+}
+
+func (x *Result) GetRetransmits() int32 {
+	if x != nil {
+		return x.Retransmits
+	}
+	return 0
+}
+
+func (x *Result) GetHasPath() bool {
+	if x != nil {
+		return x.HasPath
+	}
+	return false
+}
+
+var File_httpstat_proto protoreflect.FileDescriptor
+
+var file_httpstat_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x68, 0x74, 0x74, 0x70, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x68, 0x74, 0x74, 0x70, 0x73, 0x74, 0x61, 0x74, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf1, 0x0a, 0x0a, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x6c, 0x6f,
+	0x6f, 0x6b, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70,
+	0x12, 0x40, 0x0a, 0x0e, 0x74, 0x63, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x74, 0x63, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0d, 0x74, 0x6c, 0x73, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x68,
+	0x61, 0x6b, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x6c, 0x73, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61,
+	0x6b, 0x65, 0x12, 0x46, 0x0a, 0x11, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x44, 0x0a, 0x10, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x12, 0x44, 0x0a, 0x10, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x4c, 0x6f, 0x6f, 0x6b,
+	0x75, 0x70, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f,
+	0x74, 0x6c, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x54, 0x6c, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x72, 0x65, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x52, 0x65, 0x75, 0x73, 0x65, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x69, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x76, 0x69, 0x61, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x69, 0x61, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6c, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x6c, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72,
+	0x5f, 0x73, 0x75, 0x69, 0x74, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x74, 0x6c,
+	0x73, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x53, 0x75, 0x69, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x0f,
+	0x74, 0x6c, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6c, 0x73, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6c, 0x73, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x64,
+	0x64, 0x72, 0x12, 0x36, 0x0a, 0x09, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x6f,
+	0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x6e, 0x6f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x64,
+	0x5f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64,
+	0x69, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x6b, 0x65, 0x72, 0x6e,
+	0x65, 0x6c, 0x5f, 0x72, 0x74, 0x74, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x52,
+	0x74, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x6d, 0x69, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x1e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x50, 0x61, 0x74, 0x68, 0x42,
+	0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6a, 0x6f,
+	0x6e, 0x34, 0x68, 0x7a, 0x2f, 0x67, 0x6f, 0x2d, 0x68, 0x74, 0x74, 0x70, 0x73, 0x74, 0x61, 0x74,
+	0x2f, 0x68, 0x74, 0x74, 0x70, 0x73, 0x74, 0x61, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_httpstat_proto_rawDescOnce sync.Once
+	file_httpstat_proto_rawDescData = file_httpstat_proto_rawDesc
+)
+
+func file_httpstat_proto_rawDescGZIP() []byte {
+	file_httpstat_proto_rawDescOnce.Do(func() {
+		file_httpstat_proto_rawDescData = protoimpl.X.CompressGZIP(file_httpstat_proto_rawDescData)
+	})
+	return file_httpstat_proto_rawDescData
+}
+
+var file_httpstat_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_httpstat_proto_goTypes = []interface{}{
+	(*Result)(nil),                // 0: httpstat.Result
+	(*durationpb.Duration)(nil),   // 1: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_httpstat_proto_depIdxs = []int32{
+	1,  // 0: httpstat.Result.dns_lookup:type_name -> google.protobuf.Duration
+	1,  // 1: httpstat.Result.tcp_connection:type_name -> google.protobuf.Duration
+	1,  // 2: httpstat.Result.tls_handshake:type_name -> google.protobuf.Duration
+	1,  // 3: httpstat.Result.server_processing:type_name -> google.protobuf.Duration
+	1,  // 4: httpstat.Result.content_transfer:type_name -> google.protobuf.Duration
+	1,  // 5: httpstat.Result.headers_complete:type_name -> google.protobuf.Duration
+	1,  // 6: httpstat.Result.name_lookup:type_name -> google.protobuf.Duration
+	1,  // 7: httpstat.Result.connect:type_name -> google.protobuf.Duration
+	1,  // 8: httpstat.Result.pretransfer:type_name -> google.protobuf.Duration
+	1,  // 9: httpstat.Result.start_transfer:type_name -> google.protobuf.Duration
+	1,  // 10: httpstat.Result.total:type_name -> google.protobuf.Duration
+	2,  // 11: httpstat.Result.started_at:type_name -> google.protobuf.Timestamp
+	2,  // 12: httpstat.Result.ended_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: httpstat.Result.idle_time:type_name -> google.protobuf.Duration
+	1,  // 14: httpstat.Result.kernel_rtt:type_name -> google.protobuf.Duration
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_httpstat_proto_init() }
+func file_httpstat_proto_init() {
+	if File_httpstat_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_httpstat_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_httpstat_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_httpstat_proto_goTypes,
+		DependencyIndexes: file_httpstat_proto_depIdxs,
+		MessageInfos:      file_httpstat_proto_msgTypes,
+	}.Build()
+	File_httpstat_proto = out.File
+	file_httpstat_proto_rawDesc = nil
+	file_httpstat_proto_goTypes = nil
+	file_httpstat_proto_depIdxs = nil
+}
@@ -0,0 +1,37 @@
+package httpstat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ViaProxy returns true when the request was sent through a proxy.
+// It's detected by Transport when its Base is a *http.Transport.
+func (r *Result) ViaProxy() bool {
+	return r.viaProxy
+}
+
+// Redirects returns the number of redirects which were followed before
+// the request. It's detected by Transport.
+func (r *Result) Redirects() int {
+	return r.redirects
+}
+
+// Path describes the route of the request: "direct", "via-proxy",
+// "3-redirect-chain" for a request after 3 redirects, or both joined by
+// "+" like "via-proxy+3-redirect-chain". It's only detected by Transport,
+// with WithHTTPStat alone it's always "direct".
+func (r *Result) Path() string {
+	var parts []string
+	if r.viaProxy {
+		parts = append(parts, "via-proxy")
+	}
+	if r.redirects > 0 {
+		parts = append(parts, fmt.Sprintf("%d-redirect-chain", r.redirects))
+	}
+
+	if len(parts) == 0 {
+		return "direct"
+	}
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,113 @@
+package httpstat
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// lastResult makes a GET request to urlStr through a Transport with base
+// and returns the Result of the last request.
+func lastResult(t *testing.T, base *http.Transport, urlStr string) *Result {
+	var result *Result
+	client := &http.Client{
+		Transport: &Transport{
+			Base: base,
+			OnComplete: func(req *http.Request, r *Result) {
+				result = r
+			},
+		},
+	}
+
+	res, err := client.Get(urlStr)
+	if err != nil {
+		t.Fatal("client.Get failed:", err)
+	}
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+	res.Body.Close()
+
+	if result == nil {
+		t.Fatal("OnComplete was not called")
+	}
+	return result
+}
+
+func TestPath(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		if _, err := fmt.Sscanf(r.URL.Path, "/redirect/%d", &n); err == nil && n > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/redirect/%d", n-1), http.StatusFound)
+			return
+		}
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "proxied")
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal("url.Parse failed:", err)
+	}
+	proxied := DefaultTransport()
+	proxied.Proxy = http.ProxyURL(proxyURL)
+
+	direct := DefaultTransport()
+	direct.Proxy = nil
+
+	cases := []struct {
+		base   *http.Transport
+		urlStr string
+		want   string
+	}{
+		{direct, ts.URL, "direct"},
+		{proxied, ts.URL, "via-proxy"},
+		{direct, ts.URL + "/redirect/3", "3-redirect-chain"},
+	}
+
+	for _, tc := range cases {
+		if got := lastResult(t, tc.base, tc.urlStr).Path(); got != tc.want {
+			t.Fatalf("Path of %s = %q, want %q", tc.urlStr, got, tc.want)
+		}
+	}
+
+	both := &Result{viaProxy: true, redirects: 2}
+	if got, want := both.Path(), "via-proxy+2-redirect-chain"; got != want {
+		t.Fatalf("Path = %q, want %q", got, want)
+	}
+}
+
+func TestExplain_Path(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	if got := lastResult(t, DefaultTransport(), ts.URL).Explain(); !strings.Contains(got, "Path:           direct") {
+		t.Fatalf("Explain should contain the path, got:\n%s", got)
+	}
+
+	// Path is unknown without Transport.
+	if got := testResult().Explain(); strings.Contains(got, "Path:") {
+		t.Fatalf("Explain should not contain the path, got:\n%s", got)
+	}
+}
+
+func TestExplain_PathFromSnapshot(t *testing.T) {
+	r := &Result{hasPath: true, viaProxy: true, redirects: 2}
+
+	want := "Path:           via-proxy+2-redirect-chain"
+	if got := FromSnapshot(r.Snapshot()).Explain(); !strings.Contains(got, want) {
+		t.Fatalf("Explain should contain %q, got:\n%s", want, got)
+	}
+}
@@ -0,0 +1,225 @@
+package httpstat
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Estimator accumulates durations one at a time and estimates their
+// p-th percentile (0-100). LinearInterpolation and NearestRank keep all
+// durations and are exact, Sketch has bounded memory for huge streams.
+// Other algorithms, like a t-digest, can be plugged into an Aggregator by
+// implementing it.
+type Estimator interface {
+	Add(d time.Duration)
+	Percentile(p float64) time.Duration
+}
+
+// samples keeps all added durations and sorts them on demand.
+type samples struct {
+	ds     []time.Duration
+	sorted bool
+}
+
+func (s *samples) add(d time.Duration) {
+	s.ds = append(s.ds, d)
+	s.sorted = false
+}
+
+func (s *samples) sortedDurations() []time.Duration {
+	if !s.sorted {
+		sort.Slice(s.ds, func(i, j int) bool { return s.ds[i] < s.ds[j] })
+		s.sorted = true
+	}
+	return s.ds
+}
+
+// LinearInterpolation is an Estimator which interpolates linearly between
+// the closest ranks of the sorted durations. It is exact and the default
+// of Aggregator.
+type LinearInterpolation struct {
+	samples
+}
+
+// Add implements Estimator.
+func (e *LinearInterpolation) Add(d time.Duration) {
+	e.add(d)
+}
+
+// Percentile implements Estimator.
+func (e *LinearInterpolation) Percentile(p float64) time.Duration {
+	return interpolate(e.sortedDurations(), p)
+}
+
+// interpolate returns the p-th percentile of sorted by linear
+// interpolation between the closest ranks.
+func interpolate(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	switch {
+	case p <= 0:
+		return sorted[0]
+	case p >= 100:
+		return sorted[len(sorted)-1]
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower == len(sorted)-1 {
+		return sorted[lower]
+	}
+
+	frac := rank - float64(lower)
+	return sorted[lower] + time.Duration(frac*float64(sorted[lower+1]-sorted[lower]))
+}
+
+// NearestRank is an Estimator which returns the smallest duration such
+// that at least p percent of the durations are less than or equal to it.
+// The result is always one of the durations.
+type NearestRank struct {
+	samples
+}
+
+// Add implements Estimator.
+func (e *NearestRank) Add(d time.Duration) {
+	e.add(d)
+}
+
+// Percentile implements Estimator.
+func (e *NearestRank) Percentile(p float64) time.Duration {
+	sorted := e.sortedDurations()
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	switch {
+	case rank < 1:
+		rank = 1
+	case rank > len(sorted):
+		rank = len(sorted)
+	}
+
+	return sorted[rank-1]
+}
+
+// Sketch is an Estimator with bounded memory. Durations are counted in
+// logarithmic buckets, so each percentile is within the relative
+// accuracy of a duration of the right rank (like DDSketch). Memory grows
+// with the logarithm of the range of durations, not with their number.
+type Sketch struct {
+	gamma    float64
+	logGamma float64
+
+	buckets map[int]uint64
+	zeros   uint64
+	count   uint64
+}
+
+// NewSketch returns a Sketch with the given relative accuracy, e.g. 0.01
+// for 1%. It must be between 0 and 1.
+func NewSketch(relativeAccuracy float64) *Sketch {
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	return &Sketch{
+		gamma:    gamma,
+		logGamma: math.Log(gamma),
+		buckets:  make(map[int]uint64),
+	}
+}
+
+// Add implements Estimator.
+func (s *Sketch) Add(d time.Duration) {
+	s.count++
+	if d <= 0 {
+		s.zeros++
+		return
+	}
+
+	// Bucket i holds durations in (gamma^(i-1), gamma^i].
+	s.buckets[int(math.Ceil(math.Log(float64(d))/s.logGamma))]++
+}
+
+// Percentile implements Estimator.
+func (s *Sketch) Percentile(p float64) time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+
+	switch {
+	case p < 0:
+		p = 0
+	case p > 100:
+		p = 100
+	}
+
+	rank := p / 100 * float64(s.count-1)
+	if rank < float64(s.zeros) {
+		return 0
+	}
+
+	indexes := make([]int, 0, len(s.buckets))
+	for i := range s.buckets {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	seen := s.zeros
+	for _, i := range indexes {
+		seen += s.buckets[i]
+		if float64(seen) > rank {
+			return time.Duration(2 * math.Pow(s.gamma, float64(i)) / (s.gamma + 1))
+		}
+	}
+
+	return time.Duration(2 * math.Pow(s.gamma, float64(indexes[len(indexes)-1])) / (s.gamma + 1))
+}
+
+// aggregatedNames are the phases and timelines kept by Aggregator.
+var aggregatedNames = append(append([]string(nil), phaseNames...),
+	"NameLookup", "Connect", "Pretransfer", "StartTransfer", "Total")
+
+// Aggregator computes percentiles of each phase over the Results added to
+// it. Durations are only kept by its Estimators, so with a bounded memory
+// Estimator like Sketch it can aggregate an arbitrarily long stream.
+// It is not safe for concurrent use.
+type Aggregator struct {
+	// NewEstimator returns the Estimator for each phase.
+	// If nil, LinearInterpolation is used.
+	NewEstimator func() Estimator
+
+	estimators map[string]Estimator
+}
+
+// Add adds the durations of r.
+func (a *Aggregator) Add(r *Result) {
+	if a.estimators == nil {
+		a.estimators = make(map[string]Estimator, len(aggregatedNames))
+		for _, name := range aggregatedNames {
+			a.estimators[name] = a.newEstimator()
+		}
+	}
+
+	for _, name := range aggregatedNames {
+		a.estimators[name].Add(r.duration(name))
+	}
+}
+
+// Percentile returns the p-th percentile (0-100) of the given phase over
+// the added Results. It returns zero when nothing has been added.
+func (a *Aggregator) Percentile(phase string, p float64) time.Duration {
+	e, ok := a.estimators[phase]
+	if !ok {
+		return 0
+	}
+	return e.Percentile(p)
+}
+
+func (a *Aggregator) newEstimator() Estimator {
+	if a.NewEstimator != nil {
+		return a.NewEstimator()
+	}
+	return &LinearInterpolation{}
+}
@@ -0,0 +1,119 @@
+package httpstat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimators(t *testing.T) {
+	linear := &LinearInterpolation{}
+	rank := &NearestRank{}
+	sketch := NewSketch(0.01)
+
+	for _, e := range []Estimator{linear, rank, sketch} {
+		if got := e.Percentile(50); got != 0 {
+			t.Fatalf("%T of empty = %s, want 0", e, got)
+		}
+	}
+
+	// 1..1000ms in reverse order.
+	for i := 1000; i >= 1; i-- {
+		d := time.Duration(i) * time.Millisecond
+		linear.Add(d)
+		rank.Add(d)
+		sketch.Add(d)
+	}
+
+	cases := []struct {
+		p      float64
+		linear time.Duration
+		rank   time.Duration
+	}{
+		{0, 1 * time.Millisecond, 1 * time.Millisecond},
+		{50, 500500 * time.Microsecond, 500 * time.Millisecond},
+		{90, 900100 * time.Microsecond, 900 * time.Millisecond},
+		{99, 990010 * time.Microsecond, 990 * time.Millisecond},
+		{100, 1000 * time.Millisecond, 1000 * time.Millisecond},
+	}
+
+	for _, tc := range cases {
+		l := linear.Percentile(tc.p)
+		if diff := l - tc.linear; diff < -time.Microsecond || diff > time.Microsecond {
+			t.Fatalf("p%v LinearInterpolation = %s, want %s", tc.p, l, tc.linear)
+		}
+
+		if got := rank.Percentile(tc.p); got != tc.rank {
+			t.Fatalf("p%v NearestRank = %s, want %s", tc.p, got, tc.rank)
+		}
+
+		// Exact methods are within one rank of each other.
+		if diff := l - tc.rank; diff < -time.Millisecond || diff > time.Millisecond {
+			t.Fatalf("p%v exact methods differ by %s", tc.p, diff)
+		}
+
+		// Sketch is within its 1% accuracy plus one rank.
+		s := sketch.Percentile(tc.p)
+		if diff := float64(s-l) / float64(l); diff < -0.02 || diff > 0.02 {
+			t.Fatalf("p%v Sketch = %s, want within 2%% of %s", tc.p, s, l)
+		}
+	}
+}
+
+func TestSketch_BoundedMemory(t *testing.T) {
+	sketch := NewSketch(0.01)
+	for i := 0; i < 100000; i++ {
+		sketch.Add(time.Duration(i%1000+1) * time.Millisecond)
+	}
+	sketch.Add(0)
+
+	// 1ms..1s spans about 350 buckets with 1% accuracy.
+	if n := len(sketch.buckets); n > 400 {
+		t.Fatalf("got %d buckets, want at most 400", n)
+	}
+
+	if got := sketch.Percentile(0); got != 0 {
+		t.Fatalf("p0 = %s, want 0", got)
+	}
+}
+
+// fixedEstimator always returns d.
+type fixedEstimator time.Duration
+
+func (f fixedEstimator) Add(d time.Duration) {}
+
+func (f fixedEstimator) Percentile(p float64) time.Duration {
+	return time.Duration(f)
+}
+
+func TestAggregator_Estimator(t *testing.T) {
+	var a Aggregator
+	if got := a.Percentile("Total", 99); got != 0 {
+		t.Fatalf("Percentile of empty = %s, want 0", got)
+	}
+
+	a.Add(testResult())
+	a.Add(testResult())
+	if got, want := a.Percentile("Total", 99), 100*time.Millisecond; got != want {
+		t.Fatalf("Percentile = %s, want %s", got, want)
+	}
+
+	streaming := Aggregator{
+		NewEstimator: func() Estimator { return NewSketch(0.01) },
+	}
+	for i := 0; i < 1000; i++ {
+		streaming.Add(testResult())
+	}
+	if got := streaming.Percentile("ServerProcessing", 50); got < 39*time.Millisecond || got > 41*time.Millisecond {
+		t.Fatalf("Percentile = %s, want about 40ms", got)
+	}
+
+	fixed := Aggregator{
+		NewEstimator: func() Estimator { return fixedEstimator(time.Second) },
+	}
+	fixed.Add(testResult())
+	for name, d := range fixed.SuggestBudget(99) {
+		if d != time.Second {
+			t.Fatalf("%s = %s, want %s", name, d, time.Second)
+		}
+	}
+}
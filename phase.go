@@ -0,0 +1,86 @@
+package httpstat
+
+import "time"
+
+// phaseNames are the names of the phases of a request in waterfall order.
+// Functions which take a phase name also accept the timeline names
+// (NameLookup, Connect, Pretransfer, StartTransfer and Total).
+var phaseNames = []string{
+	"DNSLookup",
+	"TCPConnection",
+	"TLSHandshake",
+	"ServerProcessing",
+	"ContentTransfer",
+}
+
+type phase struct {
+	name     string
+	duration time.Duration
+}
+
+// phases returns the duration of each phase in waterfall order.
+func (r *Result) phases() []phase {
+	phases := make([]phase, 0, len(phaseNames))
+	for _, name := range phaseNames {
+		phases = append(phases, phase{name: name, duration: r.duration(name)})
+	}
+	return phases
+}
+
+// duration returns the duration of the phase or timeline with the given
+// name. It returns zero for an unknown name.
+func (r *Result) duration(name string) time.Duration {
+	switch name {
+	case "DNSLookup":
+		return r.DNSLookup
+	case "TCPConnection":
+		return r.TCPConnection
+	case "TLSHandshake":
+		return r.TLSHandshake
+	case "ServerProcessing":
+		return r.ServerProcessing
+	case "ContentTransfer":
+		return r.contentTransfer
+	case "NameLookup":
+		return r.NameLookup
+	case "Connect":
+		return r.Connect
+	case "Pretransfer":
+		return r.Pretransfer
+	case "StartTransfer":
+		return r.StartTransfer
+	case "Total":
+		return r.total
+	}
+	return 0
+}
+
+// DefaultBalanceRatio is the ratio used by IsBalanced.
+const DefaultBalanceRatio = 10.0
+
+// IsBalanced returns true when no phase dominates the request, that is
+// the largest phase is at most DefaultBalanceRatio times the second
+// largest non-zero phase.
+func (r *Result) IsBalanced() bool {
+	return r.IsBalancedWithin(DefaultBalanceRatio)
+}
+
+// IsBalancedWithin is like IsBalanced but uses the given ratio. A Result
+// with less than two non-zero phases is balanced, since there is nothing
+// to compare.
+func (r *Result) IsBalancedWithin(ratio float64) bool {
+	var first, second time.Duration
+	for _, p := range r.phases() {
+		switch {
+		case p.duration > first:
+			first, second = p.duration, first
+		case p.duration > second:
+			second = p.duration
+		}
+	}
+
+	if second <= 0 {
+		return true
+	}
+	return float64(first) <= ratio*float64(second)
+}
@@ -0,0 +1,32 @@
+package httpstat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsBalanced(t *testing.T) {
+	balanced := testResult()
+	if !balanced.IsBalanced() {
+		t.Fatal("IsBalanced should be true")
+	}
+
+	skewed := testResult()
+	skewed.ServerProcessing = 2 * time.Second
+	if skewed.IsBalanced() {
+		t.Fatal("IsBalanced should be false when ServerProcessing dominates")
+	}
+
+	// 2s is 80 times of 25ms content transfer.
+	if !skewed.IsBalancedWithin(100) {
+		t.Fatal("IsBalancedWithin(100) should be true")
+	}
+	if skewed.IsBalancedWithin(50) {
+		t.Fatal("IsBalancedWithin(50) should be false")
+	}
+
+	single := &Result{ServerProcessing: time.Second}
+	if !single.IsBalanced() {
+		t.Fatal("IsBalanced should be true with a single phase")
+	}
+}
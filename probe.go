@@ -0,0 +1,60 @@
+package httpstat
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ProbeKeepalive sends req with client once, then again after sleeping
+// each of gaps, and returns the Result of each request. Comparing
+// IsReused and IdleTime of the Results shows after which idle gap the
+// connection is no longer reused, e.g. to tune IdleConnTimeout.
+//
+// The request body is re-created by req.GetBody for every request, so
+// req should have no body or GetBody set. When a request fails, probing
+// stops and the Results so far are returned.
+func ProbeKeepalive(client *http.Client, req *http.Request, gaps []time.Duration) []*Result {
+	results := make([]*Result, 0, len(gaps)+1)
+
+	for i := 0; i <= len(gaps); i++ {
+		if i > 0 {
+			time.Sleep(gaps[i-1])
+		}
+
+		r, err := probe(client, req)
+		if err != nil {
+			break
+		}
+		results = append(results, r)
+	}
+
+	return results
+}
+
+func probe(client *http.Client, req *http.Request) (*Result, error) {
+	r := &Result{}
+	req = req.Clone(WithHTTPStat(req.Context(), r))
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Body must be read until EOF to put the connection back to the pool.
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		return nil, err
+	}
+	r.End(time.Now())
+
+	return r, nil
+}
@@ -0,0 +1,52 @@
+package httpstat
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProbeKeepalive(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	transport := DefaultTransport()
+	transport.IdleConnTimeout = 100 * time.Millisecond
+	client := &http.Client{Transport: transport}
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal("NewRequest failed:", err)
+	}
+
+	gaps := []time.Duration{10 * time.Millisecond, 300 * time.Millisecond}
+	results := ProbeKeepalive(client, req, gaps)
+
+	if len(results) != len(gaps)+1 {
+		t.Fatalf("got %d results, want %d", len(results), len(gaps)+1)
+	}
+
+	if results[0].IsReused() {
+		t.Fatal("#0 IsReused should be false")
+	}
+
+	// Within idle timeout.
+	if !results[1].IsReused() {
+		t.Fatal("#1 IsReused should be true")
+	}
+	if got := results[1].IdleTime(); got < gaps[0] {
+		t.Fatalf("#1 IdleTime = %s, want at least %s", got, gaps[0])
+	}
+
+	// After idle timeout.
+	if results[2].IsReused() {
+		t.Fatal("#2 IsReused should be false")
+	}
+	if got := results[2].IdleTime(); got != 0 {
+		t.Fatalf("#2 IdleTime = %s, want 0", got)
+	}
+}
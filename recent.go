@@ -0,0 +1,48 @@
+package httpstat
+
+import "sync"
+
+// Recent is a ring buffer which keeps the most recent Results.
+// It is safe for concurrent use.
+type Recent struct {
+	mu      sync.Mutex
+	results []*Result
+	next    int
+	full    bool
+}
+
+// NewRecent returns a Recent which keeps up to size Results.
+func NewRecent(size int) *Recent {
+	if size < 1 {
+		size = 1
+	}
+	return &Recent{
+		results: make([]*Result, size),
+	}
+}
+
+// Add adds r, dropping the oldest Result when the buffer is full.
+func (rc *Recent) Add(r *Result) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.results[rc.next] = r
+	rc.next = (rc.next + 1) % len(rc.results)
+	if rc.next == 0 {
+		rc.full = true
+	}
+}
+
+// Results returns the kept Results, oldest first.
+func (rc *Recent) Results() []*Result {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if !rc.full {
+		return append([]*Result(nil), rc.results[:rc.next]...)
+	}
+
+	results := make([]*Result, 0, len(rc.results))
+	results = append(results, rc.results[rc.next:]...)
+	return append(results, rc.results[:rc.next]...)
+}
@@ -0,0 +1,32 @@
+package httpstat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecent(t *testing.T) {
+	recent := NewRecent(3)
+
+	if got := recent.Results(); len(got) != 0 {
+		t.Fatalf("got %d results, want 0", len(got))
+	}
+
+	var results []*Result
+	for i := 1; i <= 5; i++ {
+		r := &Result{total: time.Duration(i) * time.Millisecond}
+		results = append(results, r)
+		recent.Add(r)
+	}
+
+	got := recent.Results()
+	if len(got) != 3 {
+		t.Fatalf("got %d results, want 3", len(got))
+	}
+
+	for i, r := range got {
+		if want := results[i+2]; r != want {
+			t.Fatalf("#%d got total %s, want %s", i, r.total, want.total)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package httpstat
+
+// SetRoute sets the route template of the request (e.g. /users/{id}), so
+// Results of high cardinality URLs can be grouped by GroupByRoute.
+func (r *Result) SetRoute(template string) {
+	r.route = template
+}
+
+// Route returns the route template set by SetRoute.
+func (r *Result) Route() string {
+	return r.route
+}
+
+// GroupByRoute groups results by their route template. Results without a
+// route are grouped under the empty string.
+func GroupByRoute(results []*Result) map[string][]*Result {
+	groups := make(map[string][]*Result)
+	for _, r := range results {
+		groups[r.route] = append(groups[r.route], r)
+	}
+	return groups
+}
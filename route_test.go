@@ -0,0 +1,37 @@
+package httpstat
+
+import "testing"
+
+func TestGroupByRoute(t *testing.T) {
+	var results []*Result
+	for _, route := range []string{"/users/{id}", "/users/{id}", "/orders/{id}", "", "/users/{id}"} {
+		r := &Result{}
+		if route != "" {
+			r.SetRoute(route)
+		}
+		results = append(results, r)
+	}
+
+	groups := GroupByRoute(results)
+
+	want := map[string]int{
+		"/users/{id}":  3,
+		"/orders/{id}": 1,
+		"":             1,
+	}
+
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+
+	for route, n := range want {
+		if got := len(groups[route]); got != n {
+			t.Fatalf("%q has %d results, want %d", route, got, n)
+		}
+		for _, r := range groups[route] {
+			if r.Route() != route {
+				t.Fatalf("Route = %q, want %q", r.Route(), route)
+			}
+		}
+	}
+}
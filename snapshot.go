@@ -0,0 +1,154 @@
+package httpstat
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Snapshot is a plain copy of a Result, e.g. for serialization.
+// Durations are in nanoseconds when encoded as JSON.
+type Snapshot struct {
+	DNSLookup        time.Duration `json:"dns_lookup"`
+	TCPConnection    time.Duration `json:"tcp_connection"`
+	TLSHandshake     time.Duration `json:"tls_handshake"`
+	ServerProcessing time.Duration `json:"server_processing"`
+	ContentTransfer  time.Duration `json:"content_transfer"`
+	HeadersComplete  time.Duration `json:"headers_complete"`
+
+	NameLookup    time.Duration `json:"name_lookup"`
+	Connect       time.Duration `json:"connect"`
+	Pretransfer   time.Duration `json:"pretransfer"`
+	StartTransfer time.Duration `json:"start_transfer"`
+	Total         time.Duration `json:"total"`
+
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+
+	IsTLS       bool `json:"is_tls"`
+	IsReused    bool `json:"is_reused"`
+	IsFinalized bool `json:"is_finalized"`
+	NoNetwork   bool `json:"no_network"`
+
+	IdleTime time.Duration `json:"idle_time"`
+
+	// HasPath is true when ViaProxy and Redirects are detected by
+	// Transport.
+	HasPath   bool   `json:"has_path"`
+	ViaProxy  bool   `json:"via_proxy"`
+	Redirects int    `json:"redirects"`
+	Route     string `json:"route,omitempty"`
+
+	TLSVersion     uint16 `json:"tls_version,omitempty"`
+	TLSCipherSuite uint16 `json:"tls_cipher_suite,omitempty"`
+	TLSServerName  string `json:"tls_server_name,omitempty"`
+	DidResume      bool   `json:"did_resume"`
+	TLSError       string `json:"tls_error,omitempty"`
+	RemoteAddr     string `json:"remote_addr,omitempty"`
+
+	// KernelRTT and Retransmits are only set by SetTCPInfo.
+	KernelRTT   time.Duration `json:"kernel_rtt,omitempty"`
+	Retransmits int           `json:"retransmits,omitempty"`
+}
+
+// Snapshot returns a copy of the result.
+func (r *Result) Snapshot() Snapshot {
+	s := Snapshot{
+		DNSLookup:        r.DNSLookup,
+		TCPConnection:    r.TCPConnection,
+		TLSHandshake:     r.TLSHandshake,
+		ServerProcessing: r.ServerProcessing,
+		ContentTransfer:  r.contentTransfer,
+		HeadersComplete:  r.HeadersComplete,
+
+		NameLookup:    r.NameLookup,
+		Connect:       r.Connect,
+		Pretransfer:   r.Pretransfer,
+		StartTransfer: r.StartTransfer,
+		Total:         r.total,
+
+		StartedAt: r.StartedAt(),
+		EndedAt:   r.EndedAt(),
+
+		IsTLS:       r.isTLS,
+		IsReused:    r.isReused,
+		IsFinalized: r.isFinalized,
+		NoNetwork:   r.noNetwork,
+
+		IdleTime: r.idleTime,
+
+		HasPath:   r.hasPath,
+		ViaProxy:  r.viaProxy,
+		Redirects: r.redirects,
+		Route:     r.route,
+
+		TLSVersion:     r.tlsVersion,
+		TLSCipherSuite: r.tlsCipherSuite,
+		TLSServerName:  r.tlsServerName,
+		DidResume:      r.didResume,
+		RemoteAddr:     r.remoteAddr,
+	}
+
+	if r.hasTCPInfo {
+		s.KernelRTT = r.rttKernel
+		s.Retransmits = r.retransmits
+	}
+
+	if r.tlsErr != nil {
+		s.TLSError = r.tlsErr.Error()
+	}
+
+	return s
+}
+
+// FromSnapshot returns a Result restored from s, e.g. after it has been
+// received from another process.
+func FromSnapshot(s Snapshot) *Result {
+	r := &Result{
+		DNSLookup:        s.DNSLookup,
+		TCPConnection:    s.TCPConnection,
+		TLSHandshake:     s.TLSHandshake,
+		ServerProcessing: s.ServerProcessing,
+		contentTransfer:  s.ContentTransfer,
+		HeadersComplete:  s.HeadersComplete,
+
+		NameLookup:    s.NameLookup,
+		Connect:       s.Connect,
+		Pretransfer:   s.Pretransfer,
+		StartTransfer: s.StartTransfer,
+		total:         s.Total,
+
+		dnsStart:     s.StartedAt,
+		transferDone: s.EndedAt,
+
+		isTLS:       s.IsTLS,
+		isReused:    s.IsReused,
+		isFinalized: s.IsFinalized,
+		noNetwork:   s.NoNetwork,
+
+		idleTime: s.IdleTime,
+
+		hasPath:   s.HasPath,
+		viaProxy:  s.ViaProxy,
+		redirects: s.Redirects,
+		route:     s.Route,
+
+		tlsVersion:     s.TLSVersion,
+		tlsCipherSuite: s.TLSCipherSuite,
+		tlsServerName:  s.TLSServerName,
+		didResume:      s.DidResume,
+		remoteAddr:     s.RemoteAddr,
+
+		mu: &sync.Mutex{},
+	}
+
+	if s.KernelRTT != 0 || s.Retransmits != 0 {
+		r.SetTCPInfo(s.KernelRTT, s.Retransmits)
+	}
+
+	if s.TLSError != "" {
+		r.tlsErr = errors.New(s.TLSError)
+	}
+
+	return r
+}
@@ -0,0 +1,154 @@
+package httpstat
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeWeightedMean returns the mean duration of the given phase where each
+// Result is weighted by the interval until the next Result started. The
+// last Result is weighted by the interval until it ended. Unlike the
+// arithmetic mean, bursts of requests do not dominate the value.
+//
+// Results are ordered by StartedAt. Results which did not start are
+// ignored. When all weights are zero, the arithmetic mean is returned.
+func TimeWeightedMean(results []*Result, phase string) time.Duration {
+	sorted := make([]*Result, 0, len(results))
+	for _, r := range results {
+		if !r.StartedAt().IsZero() {
+			sorted = append(sorted, r)
+		}
+	}
+
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartedAt().Before(sorted[j].StartedAt())
+	})
+
+	var sum, weights, mean float64
+	for i, r := range sorted {
+		var weight time.Duration
+		if i < len(sorted)-1 {
+			weight = sorted[i+1].StartedAt().Sub(r.StartedAt())
+		} else if !r.EndedAt().IsZero() {
+			weight = r.EndedAt().Sub(r.StartedAt())
+		}
+
+		d := float64(r.duration(phase))
+		sum += d * float64(weight)
+		weights += float64(weight)
+		mean += d / float64(len(sorted))
+	}
+
+	if weights == 0 {
+		return time.Duration(mean)
+	}
+	return time.Duration(sum / weights)
+}
+
+// Concurrency returns the peak and the average number of requests in
+// flight between StartedAt and EndedAt of results. The average is over the
+// span from the first start to the last end. Results which did not start
+// or were not finalized by End are ignored.
+func Concurrency(results []*Result) (peak int, avg float64) {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+
+	var (
+		events      []event
+		busy        time.Duration
+		first, last time.Time
+	)
+	for _, r := range results {
+		start, end := r.StartedAt(), r.EndedAt()
+		if start.IsZero() || end.IsZero() {
+			continue
+		}
+
+		events = append(events, event{start, 1}, event{end, -1})
+		busy += end.Sub(start)
+
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if end.After(last) {
+			last = end
+		}
+	}
+
+	// On a tie, the end comes first so touching intervals do not overlap.
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at.Equal(events[j].at) {
+			return events[i].delta < events[j].delta
+		}
+		return events[i].at.Before(events[j].at)
+	})
+
+	var inFlight int
+	for _, e := range events {
+		inFlight += e.delta
+		if inFlight > peak {
+			peak = inFlight
+		}
+	}
+
+	if span := last.Sub(first); span > 0 {
+		avg = float64(busy) / float64(span)
+	}
+
+	return peak, avg
+}
+
+// phaseDurations returns the duration of the given phase of each Result.
+func phaseDurations(results []*Result, phase string) []time.Duration {
+	ds := make([]time.Duration, 0, len(results))
+	for _, r := range results {
+		ds = append(ds, r.duration(phase))
+	}
+	return ds
+}
+
+// OutlierThreshold returns the median of the given phase over results
+// plus k times the median absolute deviation (MAD). Unlike the standard
+// deviation, MAD is robust to a long tail.
+func OutlierThreshold(results []*Result, phase string, k float64) time.Duration {
+	ds := phaseDurations(results, phase)
+	m := median(ds)
+
+	deviations := make([]time.Duration, 0, len(ds))
+	for _, d := range ds {
+		if d < m {
+			deviations = append(deviations, m-d)
+		} else {
+			deviations = append(deviations, d-m)
+		}
+	}
+	mad := median(deviations)
+
+	return m + time.Duration(k*float64(mad))
+}
+
+// Outliers returns the results whose given phase took longer than
+// OutlierThreshold.
+func Outliers(results []*Result, phase string, k float64) []*Result {
+	threshold := OutlierThreshold(results, phase, k)
+
+	var outliers []*Result
+	for _, r := range results {
+		if r.duration(phase) > threshold {
+			outliers = append(outliers, r)
+		}
+	}
+	return outliers
+}
+
+func median(ds []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return interpolate(sorted, 50)
+}
@@ -0,0 +1,115 @@
+package httpstat
+
+import (
+	"testing"
+	"time"
+)
+
+// resultAt returns a Result which started at offset from t0 and spent d
+// in ServerProcessing.
+func resultAt(t0 time.Time, offset, d time.Duration) *Result {
+	return &Result{
+		ServerProcessing: d,
+		total:            d,
+		dnsStart:         t0.Add(offset),
+		transferDone:     t0.Add(offset + d),
+	}
+}
+
+func TestTimeWeightedMean(t *testing.T) {
+	t0 := time.Now()
+
+	// A burst of fast requests followed by a slow one which is
+	// followed by a long quiet period.
+	results := []*Result{
+		resultAt(t0, 1200*time.Millisecond, 10*time.Millisecond),
+		resultAt(t0, 0, 10*time.Millisecond),
+		resultAt(t0, 200*time.Millisecond, 100*time.Millisecond),
+		resultAt(t0, 100*time.Millisecond, 10*time.Millisecond),
+		{ServerProcessing: time.Second}, // not started
+	}
+
+	// Weights are 100ms, 100ms, 1000ms and 10ms.
+	// (10*100 + 10*100 + 100*1000 + 10*10) / 1210
+	want := 102100 * time.Millisecond / 1210
+	got := TimeWeightedMean(results, "ServerProcessing")
+	if diff := got - want; diff < -time.Microsecond || diff > time.Microsecond {
+		t.Fatalf("TimeWeightedMean = %s, want %s", got, want)
+	}
+
+	arithmetic := 32500 * time.Microsecond
+	if got <= arithmetic {
+		t.Fatalf("TimeWeightedMean = %s, want more than arithmetic mean %s", got, arithmetic)
+	}
+}
+
+func TestTimeWeightedMean_ZeroWeights(t *testing.T) {
+	// Started at the same time and not finalized.
+	t0 := time.Now()
+	results := []*Result{
+		{ServerProcessing: 10 * time.Millisecond, dnsStart: t0},
+		{ServerProcessing: 30 * time.Millisecond, dnsStart: t0},
+		{ServerProcessing: time.Second}, // not started
+	}
+
+	if got, want := TimeWeightedMean(results, "ServerProcessing"), 20*time.Millisecond; got != want {
+		t.Fatalf("TimeWeightedMean = %s, want %s", got, want)
+	}
+
+	if got, want := TimeWeightedMean(nil, "ServerProcessing"), time.Duration(0); got != want {
+		t.Fatalf("TimeWeightedMean = %s, want %s", got, want)
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	t0 := time.Now()
+
+	// 0-100ms, 50-150ms, 80-120ms overlap at 80-100ms,
+	// 150-250ms only touches the second one.
+	results := []*Result{
+		resultAt(t0, 0, 100*time.Millisecond),
+		resultAt(t0, 50*time.Millisecond, 100*time.Millisecond),
+		resultAt(t0, 80*time.Millisecond, 40*time.Millisecond),
+		resultAt(t0, 150*time.Millisecond, 100*time.Millisecond),
+		{}, // not started
+	}
+
+	peak, avg := Concurrency(results)
+	if got, want := peak, 3; got != want {
+		t.Fatalf("peak = %d, want %d", got, want)
+	}
+
+	// 340ms busy over 250ms span.
+	if got, want := avg, 1.36; got < want-1e-9 || got > want+1e-9 {
+		t.Fatalf("avg = %v, want %v", got, want)
+	}
+
+	peak, avg = Concurrency(nil)
+	if peak != 0 || avg != 0 {
+		t.Fatalf("Concurrency(nil) = %d, %v, want 0, 0", peak, avg)
+	}
+}
+
+func TestOutliers(t *testing.T) {
+	var results []*Result
+	for _, ms := range []time.Duration{10, 12, 11, 9, 300, 10, 13, 8, 11, 950} {
+		results = append(results, &Result{ServerProcessing: ms * time.Millisecond})
+	}
+
+	// Median is 11ms, MAD is 1.5ms.
+	if got, want := OutlierThreshold(results, "ServerProcessing", 3), 15500*time.Microsecond; got != want {
+		t.Fatalf("OutlierThreshold = %s, want %s", got, want)
+	}
+
+	outliers := Outliers(results, "ServerProcessing", 3)
+	if len(outliers) != 2 {
+		t.Fatalf("got %d outliers, want 2", len(outliers))
+	}
+	if outliers[0] != results[4] || outliers[1] != results[9] {
+		t.Fatalf("outliers = %v, want 300ms and 950ms", []time.Duration{outliers[0].ServerProcessing, outliers[1].ServerProcessing})
+	}
+
+	if got := Outliers(nil, "ServerProcessing", 3); len(got) != 0 {
+		t.Fatalf("got %d outliers, want 0", len(got))
+	}
+}
@@ -0,0 +1,26 @@
+package httpstat
+
+import "time"
+
+// SetTCPInfo sets the round trip time estimated by the kernel and the
+// number of retransmits of the connection. On Linux they are read from
+// TCP_INFO of the connection when the first response byte arrives, so
+// this is only needed to record values from elsewhere. They can be
+// compared to TCPConnection.
+func (r *Result) SetTCPInfo(rttKernel time.Duration, retransmits int) {
+	if r.mu != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+
+	r.hasTCPInfo = true
+	r.rttKernel = rttKernel
+	r.retransmits = retransmits
+}
+
+// TCPInfo returns the kernel round trip time and the total number of
+// retransmits of the connection. ok is false when they are not recorded,
+// e.g. on platforms other than Linux.
+func (r *Result) TCPInfo() (rttKernel time.Duration, retransmits int, ok bool) {
+	return r.rttKernel, r.retransmits, r.hasTCPInfo
+}
@@ -0,0 +1,46 @@
+package httpstat
+
+import (
+	"errors"
+	"net"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// readTCPInfo reads the round trip time and the total number of
+// retransmits from TCP_INFO of conn.
+func readTCPInfo(conn net.Conn) (time.Duration, int, error) {
+	// Unwrap TLS connection (go1.18 or later).
+	if nc, ok := conn.(interface{ NetConn() net.Conn }); ok {
+		conn = nc.NetConn()
+	}
+
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return 0, 0, errors.New("httpstat: connection does not expose its socket")
+	}
+
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		info    *unix.TCPInfo
+		sockErr error
+	)
+	err = rc.Control(func(fd uintptr) {
+		info, sockErr = unix.GetsockoptTCPInfo(int(fd), unix.IPPROTO_TCP, unix.TCP_INFO)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	if sockErr != nil {
+		return 0, 0, sockErr
+	}
+
+	// tcpi_rtt is in microseconds.
+	return time.Duration(info.Rtt) * time.Microsecond, int(info.Total_retrans), nil
+}
@@ -0,0 +1,71 @@
+package httpstat
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func getTCPInfo(t *testing.T, client *http.Client, urlStr string) *Result {
+	var result Result
+	req := NewRequest(t, urlStr, &result)
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal("client.Do failed:", err)
+	}
+
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+	res.Body.Close()
+	result.End(time.Now())
+
+	return &result
+}
+
+func TestTCPInfo_Linux(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	})
+
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+
+	cases := []struct {
+		name   string
+		client *http.Client
+		urlStr string
+	}{
+		{"HTTP", DefaultClient(), ts.URL},
+		{"HTTPS", tlsServer.Client(), tlsServer.URL},
+	}
+
+	for _, tc := range cases {
+		first := getTCPInfo(t, tc.client, tc.urlStr)
+		second := getTCPInfo(t, tc.client, tc.urlStr)
+
+		if first.IsReused() {
+			t.Fatalf("%s: first request should not reuse connection", tc.name)
+		}
+		if !second.IsReused() {
+			t.Fatalf("%s: second request should reuse connection", tc.name)
+		}
+
+		for i, r := range []*Result{first, second} {
+			rtt, retransmits, ok := r.TCPInfo()
+			if !ok {
+				t.Fatalf("%s #%d: TCPInfo should be recorded", tc.name, i)
+			}
+			if rtt < 0 || retransmits < 0 {
+				t.Fatalf("%s #%d: TCPInfo = %s, %d, should not be negative", tc.name, i, rtt, retransmits)
+			}
+		}
+	}
+}
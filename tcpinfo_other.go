@@ -0,0 +1,15 @@
+//go:build !linux
+// +build !linux
+
+package httpstat
+
+import (
+	"errors"
+	"net"
+	"time"
+)
+
+// readTCPInfo is not supported on this platform.
+func readTCPInfo(conn net.Conn) (time.Duration, int, error) {
+	return 0, 0, errors.New("httpstat: TCP_INFO is not supported on this platform")
+}
@@ -0,0 +1,49 @@
+package httpstat
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetTCPInfo(t *testing.T) {
+	r := testResult()
+
+	if _, _, ok := r.TCPInfo(); ok {
+		t.Fatal("TCPInfo should not be set")
+	}
+
+	r.SetTCPInfo(12*time.Millisecond, 3)
+
+	rtt, retransmits, ok := r.TCPInfo()
+	if !ok || rtt != 12*time.Millisecond || retransmits != 3 {
+		t.Fatalf("TCPInfo = %s, %d, %v, want 12ms, 3, true", rtt, retransmits, ok)
+	}
+
+	b, err := json.Marshal(r.Snapshot())
+	if err != nil {
+		t.Fatal("json.Marshal failed:", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal("json.Unmarshal failed:", err)
+	}
+
+	if got, want := m["kernel_rtt"], float64(12*time.Millisecond); got != want {
+		t.Fatalf("kernel_rtt = %v, want %v", got, want)
+	}
+	if got, want := m["retransmits"], float64(3); got != want {
+		t.Fatalf("retransmits = %v, want %v", got, want)
+	}
+
+	want := "Kernel RTT:     12ms (3 retransmits)"
+	if got := r.Explain(); !strings.Contains(got, want) {
+		t.Fatalf("Explain should contain %q, got:\n%s", want, got)
+	}
+
+	if got := testResult().Explain(); strings.Contains(got, "Kernel RTT") {
+		t.Fatalf("Explain should not contain kernel RTT, got:\n%s", got)
+	}
+}
@@ -0,0 +1,129 @@
+package httpstat
+
+import (
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Transport is a http.RoundTripper which measures every request made
+// through it. The Result is passed to OnComplete once the response body
+// is closed, or right away when the request fails. Requests with a context
+// from WithoutHTTPStat are passed through without being measured.
+type Transport struct {
+	// Base is the RoundTripper used to make requests.
+	// If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// OnComplete is called with the request and its Result.
+	OnComplete func(req *http.Request, r *Result)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if skipped(req.Context()) {
+		return t.base().RoundTrip(req)
+	}
+
+	r := &Result{
+		viaProxy:  t.viaProxy(req),
+		redirects: redirects(req),
+		hasPath:   true,
+	}
+	req = req.WithContext(WithHTTPStat(req.Context(), r))
+
+	res, err := t.base().RoundTrip(req)
+	if err != nil {
+		r.End(time.Now())
+		t.complete(req, r)
+		return nil, err
+	}
+	r.SetResponseReady(time.Now())
+
+	body := &transportBody{
+		ReadCloser: res.Body,
+		done: func() {
+			r.End(time.Now())
+			t.complete(req, r)
+		},
+	}
+
+	// The body of a 101 response is the upgraded connection and must
+	// stay writable (e.g. httputil.ReverseProxy requires it).
+	if rw, ok := res.Body.(io.ReadWriteCloser); ok {
+		res.Body = &transportUpgradeBody{transportBody: body, Writer: rw}
+		return res, nil
+	}
+
+	res.Body = body
+	return res, nil
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) viaProxy(req *http.Request) bool {
+	base, ok := t.base().(*http.Transport)
+	if !ok || base.Proxy == nil {
+		return false
+	}
+
+	u, err := base.Proxy(req)
+	return err == nil && u != nil
+}
+
+// redirects returns the number of redirects the client followed to
+// make req.
+func redirects(req *http.Request) int {
+	var n int
+	for res := req.Response; res != nil && res.Request != nil; res = res.Request.Response {
+		n++
+	}
+	return n
+}
+
+func (t *Transport) complete(req *http.Request, r *Result) {
+	if t.OnComplete != nil {
+		t.OnComplete(req, r)
+	}
+}
+
+// transportBody calls done once when it is closed.
+type transportBody struct {
+	io.ReadCloser
+
+	once sync.Once
+	done func()
+}
+
+func (b *transportBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
+
+// transportUpgradeBody is a transportBody which keeps the writer of an
+// upgraded connection.
+type transportUpgradeBody struct {
+	*transportBody
+	io.Writer
+}
+
+// NewSingleHostReverseProxy returns a httputil.ReverseProxy like
+// httputil.NewSingleHostReverseProxy which measures each request to the
+// upstream target. The Result is passed to onComplete after the upstream
+// response is copied to the client.
+func NewSingleHostReverseProxy(target *url.URL, onComplete func(req *http.Request, r *Result)) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = &Transport{
+		OnComplete: onComplete,
+	}
+	return proxy
+}
@@ -0,0 +1,197 @@
+package httpstat
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewSingleHostReverseProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		io.WriteString(w, "hello")
+	}))
+	defer upstream.Close()
+
+	target, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatal("url.Parse failed:", err)
+	}
+
+	results := make(chan *Result, 1)
+	proxy := NewSingleHostReverseProxy(target, func(req *http.Request, r *Result) {
+		results <- r
+	})
+	proxy.Transport.(*Transport).Base = DefaultTransport()
+
+	ts := httptest.NewServer(proxy)
+	defer ts.Close()
+
+	res, err := DefaultClient().Get(ts.URL)
+	if err != nil {
+		t.Fatal("client.Get failed:", err)
+	}
+
+	if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+		t.Fatal("io.Copy failed:", err)
+	}
+	res.Body.Close()
+
+	var result *Result
+	select {
+	case result = <-results:
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnComplete was not called")
+	}
+
+	if !result.IsFinalized() {
+		t.Fatal("IsFinalized should be true")
+	}
+
+	if result.ServerProcessing < 10*time.Millisecond {
+		t.Fatalf("ServerProcessing = %s, want at least 10ms", result.ServerProcessing)
+	}
+
+	durations := []time.Duration{
+		result.TCPConnection,
+		result.Connect,
+		result.StartTransfer,
+		result.total,
+	}
+
+	for i, d := range durations {
+		if d <= 0*time.Millisecond {
+			t.Fatalf("#%d expect %d to be non-zero", i, d)
+		}
+	}
+}
+
+func TestTransport_WithoutHTTPStat(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+	defer ts.Close()
+
+	var results []*Result
+	client := &http.Client{
+		Transport: &Transport{
+			Base: DefaultTransport(),
+			OnComplete: func(req *http.Request, r *Result) {
+				results = append(results, r)
+			},
+		},
+	}
+
+	get := func(req *http.Request) {
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal("client.Do failed:", err)
+		}
+		if _, err := io.Copy(ioutil.Discard, res.Body); err != nil {
+			t.Fatal("io.Copy failed:", err)
+		}
+		res.Body.Close()
+	}
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal("NewRequest failed:", err)
+	}
+
+	get(req.WithContext(WithoutHTTPStat(req.Context())))
+	if len(results) != 0 {
+		t.Fatalf("got %d results for opted-out request, want 0", len(results))
+	}
+
+	get(req)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+}
+
+func TestNewSingleHostReverseProxy_Upgrade(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error("Hijack failed:", err)
+			return
+		}
+		defer conn.Close()
+
+		brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		brw.Flush()
+
+		// Echo a single line back.
+		line, err := brw.ReadString('\n')
+		if err != nil {
+			return
+		}
+		brw.WriteString(line)
+		brw.Flush()
+	}))
+	defer upstream.Close()
+
+	target, err := url.Parse(upstream.URL)
+	if err != nil {
+		t.Fatal("url.Parse failed:", err)
+	}
+
+	results := make(chan *Result, 1)
+	proxy := NewSingleHostReverseProxy(target, func(req *http.Request, r *Result) {
+		results <- r
+	})
+	proxy.Transport.(*Transport).Base = DefaultTransport()
+
+	ts := httptest.NewServer(proxy)
+	defer ts.Close()
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	if err != nil {
+		t.Fatal("NewRequest failed:", err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "echo")
+
+	res, err := DefaultClient().Do(req)
+	if err != nil {
+		t.Fatal("client.Do failed:", err)
+	}
+	defer res.Body.Close()
+
+	if got, want := res.StatusCode, http.StatusSwitchingProtocols; got != want {
+		t.Fatalf("status = %d, want %d", got, want)
+	}
+
+	rw, ok := res.Body.(io.ReadWriter)
+	if !ok {
+		t.Fatal("body of 101 response should be writable")
+	}
+
+	if _, err := io.WriteString(rw, "ping\n"); err != nil {
+		t.Fatal("Write failed:", err)
+	}
+
+	buf := make([]byte, len("ping\n"))
+	if _, err := io.ReadFull(rw, buf); err != nil {
+		t.Fatal("Read failed:", err)
+	}
+	if got, want := string(buf), "ping\n"; got != want {
+		t.Fatalf("echo = %q, want %q", got, want)
+	}
+
+	// The proxy closes the upstream body once the tunnel is closed.
+	res.Body.Close()
+
+	select {
+	case r := <-results:
+		if r.TCPConnection <= 0 {
+			t.Fatalf("expect TCPConnection %d to be non-zero", r.TCPConnection)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnComplete was not called")
+	}
+}